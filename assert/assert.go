@@ -11,64 +11,32 @@ package assert
 
 import (
 	"fmt"
-	"path"
+	"math"
 	"reflect"
-	"runtime"
 	"strings"
 	"testing"
 )
 
-// Set this to false to avoid include file position in logs.
+// Set this to false to avoid include file position in logs. It is the default of the FilePosition
+// option, read only once by New when an Assertions is created, including by each package-level
+// assertion function. Changing it is not safe with t.Parallel().
+//
+// Deprecated: use the FilePosition option.
 var IncludeFilePosition = true
 
-func isTestFuncName(name string) bool {
-	p := strings.LastIndex(name, ".")
-	if p < 0 {
-		return false
-	}
-
-	name = name[p+1:]
-	return strings.HasPrefix(name, "Test")
-}
-
-// Default: skip == 0
-func assertPos(skip int) string {
-	if !IncludeFilePosition {
-		return ""
-	}
-	res := ""
-	for i := 0; i < 5; i++ {
-		pc, file, line, ok := runtime.Caller(skip + 2)
-		if !ok {
-			return ""
-		}
-		res = fmt.Sprintf("%s:%d: ", path.Base(file), line) + res
-
-		if isTestFuncName(runtime.FuncForPC(pc).Name()) {
-			break
-		}
-		skip++
-	}
-	return "\n" + res
-}
-
 func valueMessage(v reflect.Value, incLen bool) string {
-	if !v.IsValid() {
-		return "<invalid>"
-	}
-	var m string
-	switch v.Kind() {
-	case reflect.String:
-		m = fmt.Sprintf("%q", v.Interface())
-	default:
-		m = fmt.Sprintf("%+v", v.Interface())
-	}
+	m := prettyValue(v, false)
 	if incLen {
 		m = fmt.Sprintf("(len=%d)%s", v.Len(), m)
 	}
 	return m
 }
 
+// indentValue indents the lines after the first one of a multi-line value message.
+func indentValue(m string) string {
+	return strings.Replace(m, "\n", "\n  ", -1)
+}
+
 func needLen(act, exp reflect.Value) bool {
 	if !act.IsValid() || !exp.IsValid() {
 		return false
@@ -95,17 +63,26 @@ func diffMessage(name string, act, exp reflect.Value) string {
 	actMsg := valueMessage(act, incLen)
 	expMsg := valueMessage(exp, incLen)
 	if needType(act, exp) {
-		actMsg = fmt.Sprintf("%s(type=%v)", actMsg, act.Type())
-		expMsg = fmt.Sprintf("%s(type=%v)", expMsg, exp.Type())
+		if !showsType(act) {
+			actMsg = fmt.Sprintf("%s(type=%v)", actMsg, act.Type())
+		}
+		if !showsType(exp) {
+			expMsg = fmt.Sprintf("%s(type=%v)", expMsg, exp.Type())
+		}
 	}
 	msg := fmt.Sprintf("%s is expected to be %s, but got %s", name, expMsg, actMsg)
-	if len(msg) >= 80 {
-		msg = fmt.Sprintf("%s is expected to be\n  %s\nbut got\n  %s", name, expMsg, actMsg)
+	if len(msg) >= 80 || strings.ContainsRune(msg, '\n') {
+		msg = fmt.Sprintf("%s is expected to be\n  %s\nbut got\n  %s", name, indentValue(expMsg), indentValue(actMsg))
 	}
 	return msg
 }
 
+// deepValueDiff compares act and exp with the default options.
 func deepValueDiff(name string, act, exp reflect.Value) (message string, equal bool) {
+	return (&compareOptions{}).deepValueDiff(name, act, exp)
+}
+
+func (o *compareOptions) deepValueDiff(name string, act, exp reflect.Value) (message string, equal bool) {
 	if !act.IsValid() || !exp.IsValid() {
 		if act.IsValid() == exp.IsValid() {
 			return "", true
@@ -119,7 +96,7 @@ func deepValueDiff(name string, act, exp reflect.Value) (message string, equal b
 	case reflect.Array:
 		m, eq := []string(nil), true
 		for i := 0; i < act.Len(); i++ {
-			if mi, e := deepValueDiff(fmt.Sprintf("%s[%d]", name, i), act.Index(i), exp.Index(i)); !e {
+			if mi, e := o.deepValueDiff(fmt.Sprintf("%s[%d]", name, i), act.Index(i), exp.Index(i)); !e {
 				m = append(m, strings.Split(mi, "\n")...)
 				eq = false
 			}
@@ -137,7 +114,7 @@ func deepValueDiff(name string, act, exp reflect.Value) (message string, equal b
 		}
 		m, eq := []string(nil), true
 		for i := 0; i < act.Len(); i++ {
-			if mi, e := deepValueDiff(fmt.Sprintf("%s[%d]", name, i), act.Index(i), exp.Index(i)); !e {
+			if mi, e := o.deepValueDiff(fmt.Sprintf("%s[%d]", name, i), act.Index(i), exp.Index(i)); !e {
 				m = append(m, strings.Split(mi, "\n")...)
 				eq = false
 			}
@@ -153,7 +130,7 @@ func deepValueDiff(name string, act, exp reflect.Value) (message string, equal b
 			}
 			return diffMessage(name, act, exp), false
 		}
-		return deepValueDiff(name, act.Elem(), exp.Elem())
+		return o.deepValueDiff(name, act.Elem(), exp.Elem())
 	case reflect.Ptr:
 		if act.Pointer() == exp.Pointer() {
 			return "", true
@@ -161,7 +138,7 @@ func deepValueDiff(name string, act, exp reflect.Value) (message string, equal b
 		if act.IsNil() != exp.IsNil() {
 			return diffMessage(name, act, exp), false
 		}
-		return deepValueDiff(name, act.Elem(), exp.Elem())
+		return o.deepValueDiff(name, act.Elem(), exp.Elem())
 	case reflect.Struct:
 		m, eq := []string(nil), true
 		for i, n := 0, act.NumField(); i < n; i++ {
@@ -179,7 +156,7 @@ func deepValueDiff(name string, act, exp reflect.Value) (message string, equal b
 			if act.Type().Field(i).PkgPath != "" {
 				continue
 			}
-			if mi, e := deepValueDiff(fmt.Sprintf("%s.%s", name, act.Type().Field(i).Name), act.Field(i), exp.Field(i)); !e {
+			if mi, e := o.deepValueDiff(fmt.Sprintf("%s.%s", name, act.Type().Field(i).Name), act.Field(i), exp.Field(i)); !e {
 				m = append(m, strings.Split(mi, "\n")...)
 				eq = false
 			}
@@ -199,7 +176,7 @@ func deepValueDiff(name string, act, exp reflect.Value) (message string, equal b
 			if !expV.IsValid() {
 				continue
 			}
-			if mk, e := deepValueDiff(fmt.Sprintf("%s[%v]", name, valueMessage(k, false)), actV, expV); !e {
+			if mk, e := o.deepValueDiff(fmt.Sprintf("%s[%v]", name, valueMessage(k, false)), actV, expV); !e {
 				eq = false
 				m = append(m, strings.Split(mk, "\n")...)
 			}
@@ -232,6 +209,11 @@ func deepValueDiff(name string, act, exp reflect.Value) (message string, equal b
 		}
 		// Can't do better than this:
 		return diffMessage(name, act, exp), false
+	case reflect.Float32, reflect.Float64:
+		if o.numberTolerance > 0 && math.Abs(act.Float()-exp.Float()) <= o.numberTolerance {
+			return "", true
+		}
+		fallthrough
 	default:
 		if act.Interface() == exp.Interface() {
 			return "", true
@@ -241,16 +223,29 @@ func deepValueDiff(name string, act, exp reflect.Value) (message string, equal b
 }
 
 func Equal(t testing.TB, name string, act, exp interface{}) bool {
-	m, eq := deepValueDiff(name, reflect.ValueOf(act), reflect.ValueOf(exp))
+	t.Helper()
+	return New(t).Equal(name, act, exp)
+}
+
+func (a *Assertions) Equal(name string, act, exp interface{}) bool {
+	a.t.Helper()
+	m, eq := a.compareOptions(nil).deepValueDiff(name, reflect.ValueOf(act), reflect.ValueOf(exp))
 	if eq {
 		return true
 	}
-	t.Errorf("%s%s", assertPos(0), m)
+	a.fail(m)
 	return false
 }
 
 // @param expToFunc could be a func with a single input value and a bool return, or a bool value directly.
 func ValueShould(t testing.TB, name string, act interface{}, expToFunc interface{}, descIfFailed string) bool {
+	t.Helper()
+	return New(t).ValueShould(name, act, expToFunc, descIfFailed)
+}
+
+// ValueShould is the same as the package-level ValueShould.
+func (a *Assertions) ValueShould(name string, act interface{}, expToFunc interface{}, descIfFailed string) bool {
+	a.t.Helper()
 	expFunc := reflect.ValueOf(expToFunc)
 	actValue := reflect.ValueOf(act)
 	var succ bool
@@ -258,61 +253,94 @@ func ValueShould(t testing.TB, name string, act interface{}, expToFunc interface
 		succ = expFunc.Bool()
 	} else if expFunc.Kind() == reflect.Func {
 		if expFunc.Type().NumIn() != 1 {
-			t.Errorf("%sassert: expToFunc must have one parameter", assertPos(0))
+			a.fail("assert: expToFunc must have one parameter")
 			return false
 		}
 		if expFunc.Type().NumOut() != 1 {
-			t.Errorf("%sassert: expToFunc must have one return value", assertPos(0))
+			a.fail("assert: expToFunc must have one return value")
 			return false
 		}
 		if expFunc.Type().Out(0).Kind() != reflect.Bool {
-			t.Errorf("%sassert: expToFunc must return a bool", assertPos(0))
+			a.fail("assert: expToFunc must return a bool")
 			return false
 		}
 		succ = expFunc.Call([]reflect.Value{actValue})[0].Bool()
 	} else {
-		t.Errorf("%sassert: expToFunc must be a func or a bool", assertPos(0))
+		a.fail("assert: expToFunc must be a func or a bool")
 		return false
 	}
 	if !succ {
-		t.Errorf("%s%s %s: %q(type %v)", assertPos(0), name, descIfFailed,
-			fmt.Sprint(act), actValue.Type())
+		a.fail(fmt.Sprintf("%s %s: %s", name, descIfFailed, typedValueMessage(act)))
 	}
 	return succ
 }
 
+// NotEqual checks whether act is different from exp, compared as Equal does, so values of any
+// kind, including slices and maps, can be compared.
 func NotEqual(t testing.TB, name string, act, exp interface{}) bool {
-	if act == exp {
-		t.Errorf("%s%s is not expected to be %q", assertPos(0), name, fmt.Sprint(exp))
+	t.Helper()
+	return New(t).NotEqual(name, act, exp)
+}
+
+// NotEqual is the same as the package-level NotEqual.
+func (a *Assertions) NotEqual(name string, act, exp interface{}) bool {
+	a.t.Helper()
+	expV := reflect.ValueOf(exp)
+	if _, eq := a.compareOptions(nil).deepValueDiff(name, reflect.ValueOf(act), expV); eq {
+		a.fail(fmt.Sprintf("%s is not expected to be %s", name, indentValue(prettyValue(expV, false))))
 		return false
 	}
 	return true
 }
 
 func False(t testing.TB, name string, act bool) bool {
+	t.Helper()
+	return New(t).False(name, act)
+}
+
+func (a *Assertions) False(name string, act bool) bool {
+	a.t.Helper()
 	if act {
-		t.Errorf("%s%s unexpectedly got true", assertPos(0), name)
+		a.fail(fmt.Sprintf("%s unexpectedly got true", name))
 	}
 	return !act
 }
 
 func True(t testing.TB, name string, act bool) bool {
+	t.Helper()
+	return New(t).True(name, act)
+}
+
+func (a *Assertions) True(name string, act bool) bool {
+	a.t.Helper()
 	if !act {
-		t.Errorf("%s%s unexpectedly got false", assertPos(0), name)
+		a.fail(fmt.Sprintf("%s unexpectedly got false", name))
 	}
 	return act
 }
 
 func Should(t testing.TB, vl bool, showIfFailed string) bool {
+	t.Helper()
+	return New(t).Should(vl, showIfFailed)
+}
+
+func (a *Assertions) Should(vl bool, showIfFailed string) bool {
+	a.t.Helper()
 	if !vl {
-		t.Errorf("%s%s", assertPos(0), showIfFailed)
+		a.fail(showIfFailed)
 	}
 	return vl
 }
 
 func ShouldOrDie(t testing.TB, vl bool, showIfFailed string) {
+	t.Helper()
+	New(t).ShouldOrDie(vl, showIfFailed)
+}
+
+func (a *Assertions) ShouldOrDie(vl bool, showIfFailed string) {
+	a.t.Helper()
 	if !vl {
-		t.Fatalf("%s%s", assertPos(0), showIfFailed)
+		a.failNow(showIfFailed)
 	}
 }
 
@@ -336,23 +364,33 @@ func stringSliceEqual(a, b []string) bool {
 	return true
 }
 
-func linesEqual(skip int, t testing.TB, name string, act, exp reflect.Value) bool {
-	actS, expS := sliceToStrings(act), sliceToStrings(exp)
+// linesDiff returns the message of the differences between two lists of lines, or "" if they are
+// equal.
+func linesDiff(name string, actS, expS []string) string {
 	if stringSliceEqual(actS, expS) {
-		return true
+		return ""
 	}
 
-	title := fmt.Sprintf("%sUnexpected %s: ", assertPos(skip), name)
+	lines := []string{fmt.Sprintf("Unexpected %s: ", name)}
 	if len(expS) == len(actS) {
-		title = fmt.Sprintf("%sboth %d lines", title, len(expS))
+		lines[0] = fmt.Sprintf("%sboth %d lines", lines[0], len(expS))
 	} else {
-		title = fmt.Sprintf("%sexp %d, act %d lines", title, len(expS), len(actS))
+		lines[0] = fmt.Sprintf("%sexp %d, act %d lines", lines[0], len(expS), len(actS))
 	}
-	t.Error(title)
-	t.Log("  Difference(expected ---  actual +++)")
+	lines = append(lines, "  Difference(expected ---  actual +++)")
+	lines = append(lines, diffLines(actS, expS, 1, 1, func(expI, actI int) bool {
+		return expS[expI] == actS[actI]
+	})...)
+	return strings.Join(lines, "\n")
+}
 
+// diffLines returns the lines of the differences between two lists of lines, indented for a
+// "Difference(expected ---  actual +++)" header. Lines are numbered from actBase and expBase, and
+// an expected line is the same as an actual line if equal returns true.
+func diffLines(actS, expS []string, actBase, expBase int, equal func(expI, actI int) bool) []string {
+	var lines []string
 	_, expMat, actMat := match(len(expS), len(actS), func(expI, actI int) int {
-		if expS[expI] == actS[actI] {
+		if equal(expI, actI) {
 			return 0
 		}
 		return 2
@@ -364,82 +402,113 @@ func linesEqual(skip int, t testing.TB, name string, act, exp reflect.Value) boo
 	for i, j := 0, 0; i < len(expS) || j < len(actS); {
 		switch {
 		case j >= len(actS) || i < len(expS) && expMat[i] < 0:
-			t.Logf("    --- %3d: %q", i+1, expS[i])
+			lines = append(lines, fmt.Sprintf("    --- %3d: %q", i+expBase, expS[i]))
 			i++
 		case i >= len(expS) || j < len(actS) && actMat[j] < 0:
-			t.Logf("    +++ %3d: %q", j+1, actS[j])
+			lines = append(lines, fmt.Sprintf("    +++ %3d: %q", j+actBase, actS[j]))
 			j++
 		default:
-			if expS[i] != actS[j] {
-				t.Logf("    --- %3d: %q", i+1, expS[i])
-				t.Logf("    +++ %3d: %q", j+1, actS[j])
+			if !equal(i, j) {
+				lines = append(lines, fmt.Sprintf("    --- %3d: %q", i+expBase, expS[i]))
+				lines = append(lines, fmt.Sprintf("    +++ %3d: %q", j+actBase, actS[j]))
 			} // else
 			i++
 			j++
 		}
 	}
-	return false
+	return lines
+}
+
+func (a *Assertions) linesEqual(name string, act, exp reflect.Value) bool {
+	a.t.Helper()
+	if m := linesDiff(name, sliceToStrings(act), sliceToStrings(exp)); m != "" {
+		a.fail(m)
+		return false
+	}
+	return true
 }
 
 // StringEqual compares the string representation of the values.
 // If act and exp are both slices, they were matched by elements and the results are
 // presented in a diff style (if not totally equal).
 func StringEqual(t testing.TB, name string, act, exp interface{}) bool {
+	t.Helper()
+	return New(t).StringEqual(name, act, exp)
+}
+
+// StringEqual is the same as the package-level StringEqual.
+func (a *Assertions) StringEqual(name string, act, exp interface{}) bool {
+	a.t.Helper()
 	actV, expV := reflect.ValueOf(act), reflect.ValueOf(exp)
 	if actV.Kind() == reflect.Slice && expV.Kind() == reflect.Slice {
-		return linesEqual(1, t, name, actV, expV)
+		return a.linesEqual(name, actV, expV)
 	}
 	actS, expS := fmt.Sprintf("%+v", act), fmt.Sprintf("%+v", exp)
 	if actS == expS {
 		return true
 	}
 	if strings.ContainsRune(actS, '\n') || strings.ContainsRune(expS, '\n') {
-		return linesEqual(1, t, name,
+		return a.linesEqual(name,
 			reflect.ValueOf(strings.Split(actS, "\n")),
 			reflect.ValueOf(strings.Split(expS, "\n")))
 	}
-	msg := fmt.Sprintf("%s%s is expected to be %q, but got %q", assertPos(0), name,
-		fmt.Sprint(exp), fmt.Sprint(act))
+	msg := fmt.Sprintf("%s is expected to be %q, but got %q", name, fmt.Sprint(exp), fmt.Sprint(act))
 	if len(msg) >= 80 {
-		msg = fmt.Sprintf("%s%s is expected to be\n  %q\nbut got\n  %q", assertPos(0), name,
-			fmt.Sprint(exp), fmt.Sprint(act))
+		msg = fmt.Sprintf("%s is expected to be\n  %q\nbut got\n  %q", name, fmt.Sprint(exp), fmt.Sprint(act))
 	}
-	t.Error(msg)
+	a.fail(msg)
 	return false
 }
 
 func NoError(t testing.TB, err error) bool {
+	t.Helper()
+	return New(t).NoError(err)
+}
+
+func (a *Assertions) NoError(err error) bool {
+	a.t.Helper()
 	if err != nil {
-		t.Errorf("%s%v", assertPos(0), err)
+		a.fail(errorMessage(err.Error(), err))
 		return false
 	}
 	return true
 }
 
 func NoErrorOrDie(t testing.TB, err error) {
+	t.Helper()
+	New(t).NoErrorOrDie(err)
+}
+
+func (a *Assertions) NoErrorOrDie(err error) {
+	a.t.Helper()
 	if err != nil {
-		t.Fatalf("%s%v", assertPos(0), err)
+		a.failNow(errorMessage(err.Error(), err))
 	}
 }
 
 func Error(t testing.TB, err error) bool {
+	t.Helper()
+	return New(t).Error(err)
+}
+
+func (a *Assertions) Error(err error) bool {
+	a.t.Helper()
 	if err == nil {
-		t.Error("Expecting error but nil got!")
+		a.fail("Expecting error but nil got!")
 		return false
 	}
 	return true
 }
 
 func Panic(t testing.TB, name string, f func()) bool {
-	if !func() (res bool) {
-		defer func() {
-			res = recover() != nil
-		}()
-
-		f()
-		return
-	}() {
-		t.Errorf("%s%s does not panic as expected.", assertPos(0), name)
+	t.Helper()
+	return New(t).Panic(name, f)
+}
+
+func (a *Assertions) Panic(name string, f func()) bool {
+	a.t.Helper()
+	if panicked, _, _ := capturePanic(f); !panicked {
+		a.fail(fmt.Sprintf("%s does not panic as expected.", name))
 		return false
 	}
 	return true
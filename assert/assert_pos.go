@@ -0,0 +1,153 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PathStyle defines how the file of a position is shown in logs.
+type PathStyle int
+
+const (
+	// The path relative to the root of the module containing the file, e.g. "assert/assert_test.go".
+	// The base name is used if the module root is not found.
+	ModulePath PathStyle = iota
+	// The base name of the file, e.g. "assert_test.go".
+	BasePath
+	// The absolute path of the file.
+	AbsolutePath
+)
+
+// The default maximum number of stack frames to walk when looking for the test function.
+const defaultCallerDepth = 32
+
+// The directory of this package. Frames in non-test files of it are not shown in positions.
+var assertDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return path.Dir(file)
+}()
+
+// isTestFuncName returns whether the function is an entry point of tests, i.e. a Test, Benchmark,
+// Fuzz or Example function, or a method of a test suite with such names.
+func isTestFuncName(name string) bool {
+	p := strings.LastIndex(name, ".")
+	if p < 0 {
+		return false
+	}
+
+	name = name[p+1:]
+	for _, prefix := range []string{"Test", "Benchmark", "Fuzz", "Example"} {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+		return !unicode.IsLower(r)
+	}
+	return false
+}
+
+// isRunnerFuncName returns whether the function, by its full name, is in a package running tests,
+// where positions are meaningless to users. Packages of other paths ending with such names, e.g.
+// github.com/golangplus/testing, are not runners.
+func isRunnerFuncName(name string) bool {
+	for _, pkg := range []string{"testing.", "runtime.", "reflect."} {
+		if strings.HasPrefix(name, pkg) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTestingTB returns whether t is a *testing.T, *testing.B or *testing.F, which report the
+// position of the caller of the assertion themselves, honoring t.Helper().
+func isTestingTB(t testing.TB) bool {
+	typ := reflect.TypeOf(t)
+	return typ.Kind() == reflect.Ptr && typ.Elem().PkgPath() == "testing"
+}
+
+// helperFuncs returns a function telling whether a function, by its full name, was marked by
+// t.Helper(), if t implements an IsHelper(funcName string) bool method, e.g. testingp.WriterTB.
+// Helpers of other testing.TBs are not known and shown in positions.
+func helperFuncs(t testing.TB) func(funcName string) bool {
+	if h, ok := t.(interface {
+		IsHelper(funcName string) bool
+	}); ok {
+		return h.IsHelper
+	}
+	return func(string) bool {
+		return false
+	}
+}
+
+// moduleRoots caches the module root of directories, "" if not found.
+var moduleRoots sync.Map
+
+func moduleRoot(dir string) string {
+	if root, ok := moduleRoots.Load(dir); ok {
+		return root.(string)
+	}
+	root := ""
+	if _, err := os.Stat(filepath.Join(filepath.FromSlash(dir), "go.mod")); err == nil {
+		root = dir
+	} else if parent := path.Dir(dir); parent != dir {
+		root = moduleRoot(parent)
+	}
+	moduleRoots.Store(dir, root)
+	return root
+}
+
+func displayPath(file string, style PathStyle) string {
+	switch style {
+	case AbsolutePath:
+		return file
+	case ModulePath:
+		if root := moduleRoot(path.Dir(file)); root != "" {
+			return strings.TrimPrefix(file[len(root):], "/")
+		}
+	}
+	return path.Base(file)
+}
+
+// assertPos returns the positions of the call stack from the test function to the assertion, e.g.
+// "\nfoo_test.go:10: foo_test.go:20: ". Frames in this package and in functions marked by
+// t.Helper() are omitted. Returns "" if positions are not included by c, or if t reports the
+// position itself, see isTestingTB.
+func assertPos(t testing.TB, c *config) string {
+	if !c.filePosition || isTestingTB(t) {
+		return ""
+	}
+	pcs := make([]uintptr, c.maxCallerDepth)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	isHelper := helperFuncs(t)
+	res := ""
+	for {
+		frame, more := frames.Next()
+		if isRunnerFuncName(frame.Function) {
+			break
+		}
+		inAssert := path.Dir(frame.File) == assertDir && !strings.HasSuffix(frame.File, "_test.go")
+		if !inAssert && !isHelper(frame.Function) {
+			res = fmt.Sprintf("%s:%d: ", displayPath(frame.File, c.pathStyle), frame.Line) + res
+		}
+		if !more || isTestFuncName(frame.Function) {
+			break
+		}
+	}
+	if res == "" {
+		return ""
+	}
+	return "\n" + res
+}
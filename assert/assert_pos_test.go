@@ -14,27 +14,78 @@ func TestFilePosition(t *testing.T) {
 
 	Equal(bt, "v", 1, 2)
 	line := 15 // the line number of the last line
-	Equal(t, "log", string(b), fmt.Sprintf("\nassert_pos_test.go:%d: v is expected to be 2, but got 1\n", line))
+	Equal(t, "log", string(b), fmt.Sprintf("\nassert/assert_pos_test.go:%d: v is expected to be 2, but got 1\n", line))
 
 	b.Reset()
 	Panic(bt, "nonpanic", func() {})
 	line = 20 // the line number of the last line
-	Equal(t, "log", string(b), fmt.Sprintf("\nassert_pos_test.go:%d: nonpanic does not panic as expected.\n", line))
+	Equal(t, "log", string(b), fmt.Sprintf("\nassert/assert_pos_test.go:%d: nonpanic does not panic as expected.\n", line))
 
 	func(outLine int) {
 		b.Reset()
 		Equal(bt, "v", 1, 2)
 		line := 26 // the line number of the last line
-		Equal(t, "log", string(b), fmt.Sprintf("\nassert_pos_test.go:%d: assert_pos_test.go:%d: v is expected to be 2, but got 1\n", outLine, line))
+		Equal(t, "log", string(b), fmt.Sprintf("\nassert/assert_pos_test.go:%d: assert/assert_pos_test.go:%d: v is expected to be 2, but got 1\n", outLine, line))
 	}(29) // the number in parentheses is the line number of current line
 
 	b.Reset()
 	StringEqual(bt, "s", []int{1}, []int{2})
 	line = 32 // the line number of the last line
 	StringEqual(t, "log", string(b), fmt.Sprintf(`
-assert_pos_test.go:%d: Unexpected s: both 1 lines
+assert/assert_pos_test.go:%d: Unexpected s: both 1 lines
   Difference(expected ---  actual +++)
     ---   1: "2"
     +++   1: "1"
 `, line))
 }
+
+func TestFilePosition_Helper(t *testing.T) {
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+
+	helper := func() {
+		bt.Helper()
+		True(bt, "v", false)
+	}
+	helper()
+	line := 50 // the line number of the last line
+	Equal(t, "log", string(b), fmt.Sprintf("\nassert/assert_pos_test.go:%d: v unexpectedly got false\n", line))
+
+	b.Reset()
+	New(bt, FilePaths(BasePath)).True("v", false)
+	line = 55 // the line number of the last line
+	Equal(t, "log", string(b), fmt.Sprintf("\nassert_pos_test.go:%d: v unexpectedly got false\n", line))
+}
+
+func TestFilePosition_Testing(t *testing.T) {
+	t.Run("sub", func(t *testing.T) {
+		var pos string
+		helper := func() {
+			t.Helper()
+			pos = assertPos(t, &New(t).cfg)
+		}
+		helper()
+		Equal(t, "pos", pos, "")
+	})
+}
+
+func TestIsRunnerFuncName(t *testing.T) {
+	True(t, "testing", isRunnerFuncName("testing.tRunner"))
+	True(t, "runtime", isRunnerFuncName("runtime.goexit"))
+	True(t, "reflect", isRunnerFuncName("reflect.Value.Call"))
+	False(t, "testingp", isRunnerFuncName("github.com/golangplus/testing.CheckProperty"))
+	False(t, "other testing", isRunnerFuncName("example.com/testing.Helper"))
+	False(t, "test", isRunnerFuncName("example.com/pkg.TestFoo"))
+}
+
+func TestIsTestFuncName(t *testing.T) {
+	True(t, "test", isTestFuncName("example.com/pkg.TestFoo"))
+	True(t, "test", isTestFuncName("example.com/pkg.Test"))
+	True(t, "benchmark", isTestFuncName("example.com/pkg.Benchmark_foo"))
+	True(t, "fuzz", isTestFuncName("example.com/pkg.FuzzFoo"))
+	True(t, "example", isTestFuncName("example.com/pkg.ExampleFoo"))
+	True(t, "suite method", isTestFuncName("example.com/pkg.(*Suite).TestFoo"))
+	False(t, "closure", isTestFuncName("example.com/pkg.TestFoo.func1"))
+	False(t, "lower case", isTestFuncName("example.com/pkg.Testify"))
+	False(t, "non-test", isTestFuncName("example.com/pkg.Foo"))
+}
@@ -26,6 +26,8 @@ func TestSuccess(t *testing.T) {
 	}, "is not abc"))
 	True(t, "return value", ValueShould(t, "s", "abc", true, "is not abc"))
 	True(t, "return value", NotEqual(t, "v", 1, 4))
+	True(t, "return value", NotEqual(t, "slice", []int{1}, []int{1, 2}))
+	True(t, "return value", NotEqual(t, "type", int32(1), int64(1)))
 	True(t, "return value", True(t, "bool", true))
 	True(t, "return value", Should(t, true, "failed"))
 	True(t, "return value", False(t, "bool", false))
@@ -35,15 +37,13 @@ func TestSuccess(t *testing.T) {
 }
 
 func ExampleEqual() {
-	// The following two lines are for test/example of assert package itself. Use
-	// *testing.T as t in normal testing instead.
-	IncludeFilePosition = false
-	defer func() { IncludeFilePosition = true }()
-	t := &testingp.WriterTB{Writer: os.Stdout}
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
 
-	Equal(t, "v", 1, 2)
-	Equal(t, "v", 1, "1")
-	Equal(t, "m", map[string]int{"Extra": 2, "Modified": 4}, map[string]int{"Missing": 1, "Modified": 5})
+	a.Equal("v", 1, 2)
+	a.Equal("v", 1, "1")
+	a.Equal("m", map[string]int{"Extra": 2, "Modified": 4}, map[string]int{"Missing": 1, "Modified": 5})
 
 	// OUTPUT:
 	// v is expected to be 2, but got 1
@@ -55,14 +55,12 @@ func ExampleEqual() {
 }
 
 func ExampleValueShould() {
-	// The following two lines are for test/example of assert package itself. Use
-	// *testing.T as t in normal testing instead.
-	IncludeFilePosition = false
-	defer func() { IncludeFilePosition = true }()
-	t := &testingp.WriterTB{Writer: os.Stdout}
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
 
-	ValueShould(t, "s", "\xff\xfe\xfd", utf8.ValidString, "is not valid UTF8")
-	ValueShould(t, "s", "abcd", len("abcd") <= 3, "has more than 3 bytes")
+	a.ValueShould("s", "\xff\xfe\xfd", utf8.ValidString, "is not valid UTF8")
+	a.ValueShould("s", "abcd", len("abcd") <= 3, "has more than 3 bytes")
 
 	// OUTPUT:
 	// s is not valid UTF8: "\xff\xfe\xfd"(type string)
@@ -70,14 +68,12 @@ func ExampleValueShould() {
 }
 
 func ExampleStringEqual() {
-	// The following two lines are for test/example of assert package itself. Use
-	// *testing.T as t in normal testing instead.
-	IncludeFilePosition = false
-	defer func() { IncludeFilePosition = true }()
-	t := &testingp.WriterTB{Writer: os.Stdout}
-
-	StringEqual(t, "s", []int{2, 3}, []string{"1", "2"})
-	StringEqual(t, "s", `
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
+
+	a.StringEqual("s", []int{2, 3}, []string{"1", "2"})
+	a.StringEqual("s", `
 Extra
 Modified act`, `
 Modified exp
@@ -97,30 +93,31 @@ Missing`)
 }
 
 func TestFailures(t *testing.T) {
-	IncludeFilePosition = false
-	defer func() { IncludeFilePosition = true }()
 	var b bytesp.Slice
 	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
 
-	Equal(bt, "v", 1, "2")
-	NotEqual(bt, "v", 1, 1)
-	True(bt, "v", false)
-	Should(bt, false, "Should failed")
+	a.Equal("v", 1, "2")
+	a.NotEqual("v", 1, 1)
+	a.NotEqual("m", map[string][]int{"a": {1}}, map[string][]int{"a": {1}})
+	a.True("v", false)
+	a.Should(false, "Should failed")
 	Panic(t, "ShouldOrDie", func() {
-		ShouldOrDie(bt, false, "ShouldOrDie failed")
+		a.ShouldOrDie(false, "ShouldOrDie failed")
 	})
-	StringEqual(bt, "s", 1, "2")
-	False(bt, "v", true)
-	Panic(bt, "nonpanic", func() {})
-	Error(bt, nil)
-	NoError(bt, errors.New("failed"))
+	a.StringEqual("s", 1, "2")
+	a.False("v", true)
+	a.Panic("nonpanic", func() {})
+	a.Error(nil)
+	a.NoError(errors.New("failed"))
 	Panic(t, "NoErrorOrDie", func() {
-		NoErrorOrDie(bt, errors.New("failed"))
+		a.NoErrorOrDie(errors.New("failed"))
 	})
 
 	StringEqual(t, "output", "\n"+string(b), `
 v is expected to be "2"(type=string), but got 1(type=int)
-v is not expected to be "1"
+v is not expected to be 1
+m is not expected to be map[string][]int{"a": {1}}
 v unexpectedly got false
 Should failed
 ShouldOrDie failed
@@ -129,7 +126,9 @@ v unexpectedly got true
 nonpanic does not panic as expected.
 Expecting error but nil got!
 failed
+  *errors.errorString: "failed"
 failed
+  *errors.errorString: "failed"
 `)
 }
 
@@ -227,7 +226,7 @@ func TestDeepValueDiff_Message(t *testing.T) {
     some elements of v["A"].A are not expected:
       v["A"].A[0] is expected to be "2", but got "1"`)
 	shouldNotEqualWithMessage(map[string]S{"A": S{A: []string{"1"}}}, map[string]S{"B": S{A: []string{"2"}}}, `v is unexpected:
-  extra "A" -> {V:0 A:[1]}
-  missing "B" -> {V:0 A:[2]}`)
-	shouldNotEqualWithMessage((*S)(nil), &S{}, `v is expected to be &{V:0 A:[]}, but got <nil>`)
+  extra "A" -> assert.S{V: 0, A: []string{"1"}}
+  missing "B" -> assert.S{V: 0, A: []string{"2"}}`)
+	shouldNotEqualWithMessage((*S)(nil), &S{}, `v is expected to be &assert.S{V: 0, A: []string(nil)}, but got (*assert.S)(nil)`)
 }
@@ -0,0 +1,254 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golangplus/testing/clock"
+)
+
+// Verbosity controls how much detail failure messages contain.
+type Verbosity int
+
+const (
+	// Failure messages with all details, e.g. differences of values. This is the default.
+	Detailed Verbosity = iota
+	// Only the first line of failure messages.
+	Brief
+)
+
+// Option customizes an Assertions.
+type Option func(*config)
+
+type config struct {
+	filePosition   bool
+	pathStyle      PathStyle
+	maxCallerDepth int
+	color          bool
+	verbosity      Verbosity
+	compare        []CompareOption
+	// Whether to stop the test when an assertion fails.
+	fatal bool
+	// Lines of context appended to failure messages, added by With and Withf.
+	context []string
+	// Collects the failures instead of reporting them, if not nil.
+	group *Group
+	// The clock and the context of polling assertions like Eventually.
+	clock   clock.Clock
+	pollCtx context.Context
+}
+
+// FilePosition sets whether positions of the assertions are included in logs. Defaults to
+// IncludeFilePosition. Positions are never included for a *testing.T, *testing.B or *testing.F,
+// which log the position of the caller themselves, honoring t.Helper().
+func FilePosition(include bool) Option {
+	return func(c *config) {
+		c.filePosition = include
+	}
+}
+
+// FilePaths sets how files are shown in positions. Defaults to ModulePath.
+func FilePaths(style PathStyle) Option {
+	return func(c *config) {
+		c.pathStyle = style
+	}
+}
+
+// CallerDepth sets the maximum number of stack frames to walk when looking for the test function.
+// Defaults to 32, which is also used if depth is not positive.
+func CallerDepth(depth int) Option {
+	return func(c *config) {
+		if depth <= 0 {
+			depth = defaultCallerDepth
+		}
+		c.maxCallerDepth = depth
+	}
+}
+
+// Color sets whether failure messages are colorized with ANSI escape codes.
+func Color(enabled bool) Option {
+	return func(c *config) {
+		c.color = enabled
+	}
+}
+
+// MessageVerbosity sets how much detail failure messages contain.
+func MessageVerbosity(v Verbosity) Option {
+	return func(c *config) {
+		c.verbosity = v
+	}
+}
+
+// Fatal sets whether to stop the test, by t.FailNow(), when an assertion fails. For a Group, the
+// test is stopped by Done if any assertion failed.
+func Fatal(stop bool) Option {
+	return func(c *config) {
+		c.fatal = stop
+	}
+}
+
+// PollClock sets the clock used by polling assertions like Eventually. Defaults to clock.System. A
+// clock.Fake makes them deterministic. It panics when applied if c is nil.
+func PollClock(c clock.Clock) Option {
+	return func(cfg *config) {
+		if c == nil {
+			panic("assert: PollClock with a nil clock")
+		}
+		cfg.clock = c
+	}
+}
+
+// PollContext sets the context of polling assertions like Eventually, which fail when it is
+// canceled before they finish. It panics when applied if ctx is nil.
+func PollContext(ctx context.Context) Option {
+	return func(c *config) {
+		if ctx == nil {
+			panic("assert: PollContext with a nil context")
+		}
+		c.pollCtx = ctx
+	}
+}
+
+// Comparison sets the options used by all comparisons, e.g. NumberTolerance also applies to
+// floats compared by Equal. Options passed to a single assertion are applied after these ones.
+func Comparison(opts ...CompareOption) Option {
+	return func(c *config) {
+		c.compare = append(c.compare, opts...)
+	}
+}
+
+// Assertions provides the assertions on a testing.TB with a configuration. Since the
+// configuration is not shared, it is safe to use with t.Parallel(), unlike the package-level
+// variables like IncludeFilePosition.
+//
+// The package-level assertion functions are the same as the methods of New(t).
+type Assertions struct {
+	t   testing.TB
+	cfg config
+}
+
+// Require returns an Assertions on t which stops the test, by t.FailNow(), when an assertion
+// fails. The functions in package require are the same as its methods.
+func Require(t testing.TB, opts ...Option) *Assertions {
+	a := New(t, opts...)
+	a.cfg.fatal = true
+	return a
+}
+
+// New returns an Assertions on t. Options not specified default to the package-level variables.
+func New(t testing.TB, opts ...Option) *Assertions {
+	a := &Assertions{
+		t: t,
+		cfg: config{
+			filePosition:   IncludeFilePosition,
+			pathStyle:      ModulePath,
+			maxCallerDepth: defaultCallerDepth,
+			clock:          clock.System,
+			pollCtx:        context.Background(),
+		},
+	}
+	for _, opt := range opts {
+		opt(&a.cfg)
+	}
+	return a
+}
+
+// With returns an Assertions on t whose failure messages end with the key and value as context,
+// e.g. With(t, "case", i).Equal(...).
+func With(t testing.TB, key string, value interface{}) *Assertions {
+	return New(t).With(key, value)
+}
+
+// With returns a copy of a whose failure messages end with the key and value as context, in
+// addition to the context of a.
+func (a *Assertions) With(key string, value interface{}) *Assertions {
+	return a.withContext(key + ": " + indentValue(prettyValue(reflect.ValueOf(value), false)))
+}
+
+// Withf is similar to With but the context is formatted by fmt.Sprintf.
+func (a *Assertions) Withf(format string, args ...interface{}) *Assertions {
+	return a.withContext(indentValue(fmt.Sprintf(format, args...)))
+}
+
+func (a *Assertions) withContext(line string) *Assertions {
+	c := *a
+	c.cfg.context = append(append([]string(nil), a.cfg.context...), line)
+	return &c
+}
+
+func (a *Assertions) compareOptions(opts []CompareOption) *compareOptions {
+	return newCompareOptions(append(append([]CompareOption(nil), a.cfg.compare...), opts...))
+}
+
+// The ANSI escape codes used by colorize.
+const (
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorReset = "\x1b[0m"
+)
+
+// colorize highlights, in a message, the lines of expected(red) and actual(green) values in
+// diffs.
+func colorize(msg string) string {
+	lines := strings.Split(msg, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		switch {
+		case strings.HasPrefix(trimmed, "--- "), strings.HasPrefix(trimmed, "missing "):
+			lines[i] = colorRed + line + colorReset
+		case strings.HasPrefix(trimmed, "+++ "), strings.HasPrefix(trimmed, "extra "):
+			lines[i] = colorGreen + line + colorReset
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// message returns the failure message to log, with the position prepended and the context
+// appended. The context is kept with Brief verbosity.
+func (a *Assertions) message(msg string) string {
+	if a.cfg.verbosity == Brief {
+		if p := strings.IndexByte(msg, '\n'); p >= 0 {
+			msg = msg[:p]
+		}
+	}
+	if a.cfg.color {
+		msg = colorize(msg)
+	}
+	for _, line := range a.cfg.context {
+		msg += "\n  " + line
+	}
+	return assertPos(a.t, &a.cfg) + msg
+}
+
+// fail reports a failure of an assertion with the message. The test is stopped if a is created by
+// Require. The failure is collected if a belongs to a Group.
+func (a *Assertions) fail(msg string) {
+	a.t.Helper()
+	if a.cfg.group != nil {
+		a.cfg.group.add(a.message(msg))
+		return
+	}
+	if a.cfg.fatal {
+		a.failNow(msg)
+	}
+	a.t.Error(a.message(msg))
+}
+
+// failNow reports a failure of an assertion with the message and stops the test. The failures
+// collected by the Group of a, if any, are reported before it.
+func (a *Assertions) failNow(msg string) {
+	a.t.Helper()
+	if g := a.cfg.group; g != nil {
+		g.add(a.message(msg))
+		g.report(true)
+		return
+	}
+	a.t.Fatal(a.message(msg))
+}
@@ -0,0 +1,92 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func TestNew(t *testing.T) {
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+
+	New(bt, FilePosition(false), MessageVerbosity(Brief)).StringEqual("s", "a\nb", "a\nc")
+	New(bt, FilePosition(false), Color(true)).Equal("m", map[int]int{1: 1}, map[int]int{2: 2})
+	New(bt, FilePaths(BasePath)).True("v", false)
+	line := 24 // the line number of the last line
+	New(bt, FilePaths(BasePath), CallerDepth(-1)).True("v", false)
+	New(bt, FilePaths(BasePath), CallerDepth(0)).True("v", false)
+	True(t, "tolerance", New(t, Comparison(NumberTolerance(0.01))).Equal("f", []float64{1.001}, []float64{1}))
+
+	StringEqual(t, "log", "\n"+string(b), fmt.Sprintf(`
+Unexpected s: both 2 lines
+m is unexpected:
+%[1]s  extra 1 -> 1%[2]s
+%[3]s  missing 2 -> 2%[2]s
+
+assertions_test.go:%[4]d: v unexpectedly got false
+
+assertions_test.go:%[5]d: v unexpectedly got false
+
+assertions_test.go:%[6]d: v unexpectedly got false
+`, colorGreen, colorReset, colorRed, line, line+2, line+3))
+}
+
+func ExampleAssertions_With() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
+
+	for i, c := range []struct{ in, out string }{{"a", "A"}, {"b", "c"}} {
+		a.With("case", i).With("input", c.in).Equal("out", strings.ToUpper(c.in), c.out)
+	}
+
+	// OUTPUT:
+	// out is expected to be "c", but got "B"
+	//   case: 1
+	//   input: "b"
+}
+
+func TestWith(t *testing.T) {
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+
+	a := New(bt, FilePosition(false), MessageVerbosity(Brief)).Withf("seed %d", 42)
+	a.With("m", map[string]int{"a": 1}).StringEqual("s", "a\nb", "a\nc")
+	a.NoError(errors.New("failed"))
+	With(bt, "case", 2).True("v", false)
+	line := 66 // the line number of the last line
+
+	StringEqual(t, "log", "\n"+string(b), fmt.Sprintf(`
+Unexpected s: both 2 lines
+  seed 42
+  m: map[string]int{"a": 1}
+failed
+  seed 42
+
+assert/assertions_test.go:%d: v unexpectedly got false
+  case: 2
+`, line))
+}
+
+func TestNew_Parallel(t *testing.T) {
+	for i := 0; i < 4; i++ {
+		i := i
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			var b bytesp.Slice
+			New(&testingp.WriterTB{Writer: &b}, FilePosition(i%2 == 0)).True("v", false)
+			Equal(t, "has position", len(b) > len("v unexpectedly got false\n"), i%2 == 0)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// receiveResult is the outcome of receiving from a channel.
+type receiveResult int
+
+const (
+	received receiveResult = iota
+	closed
+	timedOut
+	canceled
+)
+
+// recvChan returns the reflect.Value of ch, which must be a channel that can be received from.
+func recvChan(ch interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(ch)
+	if v.Kind() != reflect.Chan || v.Type().ChanDir()&reflect.RecvDir == 0 {
+		return v, fmt.Errorf("expecting a receivable channel, but got %s", typeName(v))
+	}
+	return v, nil
+}
+
+func chanState(ch reflect.Value) string {
+	return fmt.Sprintf("(len=%d, cap=%d)", ch.Len(), ch.Cap())
+}
+
+// receive receives a value from ch, waiting for up to timeout by the clock of a. A ready value is
+// always received, even if timeout is not positive.
+func (a *Assertions) receive(ch reflect.Value, timeout time.Duration) (reflect.Value, receiveResult) {
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: ch},
+		{Dir: reflect.SelectDefault},
+	}
+	if _, v, ok := reflect.Select(cases); ok {
+		return v, received
+	} else if v.IsValid() {
+		return v, closed
+	}
+	cases[1] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(a.cfg.clock.After(timeout))}
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(a.cfg.pollCtx.Done())})
+	switch i, v, ok := reflect.Select(cases); {
+	case i == 1:
+		return reflect.Value{}, timedOut
+	case i == 2:
+		return reflect.Value{}, canceled
+	case ok:
+		return v, received
+	default:
+		return v, closed
+	}
+}
+
+func (a *Assertions) waitMessage(name string, r receiveResult, timeout time.Duration, ch reflect.Value) string {
+	if r == canceled {
+		return fmt.Sprintf("%s is not checked, canceled: %v %s", name, a.cfg.pollCtx.Err(), chanState(ch))
+	}
+	return fmt.Sprintf("%s receives nothing within %v %s", name, timeout, chanState(ch))
+}
+
+// Receives checks whether a value is received from the channel ch within timeout, and returns it.
+// See also PollClock and PollContext.
+func Receives(t testing.TB, name string, ch interface{}, timeout time.Duration) (interface{}, bool) {
+	t.Helper()
+	return New(t).Receives(name, ch, timeout)
+}
+
+// Receives is the same as the package-level Receives.
+func (a *Assertions) Receives(name string, ch interface{}, timeout time.Duration) (interface{}, bool) {
+	a.t.Helper()
+	chV, err := recvChan(ch)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return nil, false
+	}
+	switch v, r := a.receive(chV, timeout); r {
+	case received:
+		return v.Interface(), true
+	case closed:
+		a.fail(fmt.Sprintf("%s is unexpectedly closed", name))
+	default:
+		a.fail(a.waitMessage(name, r, timeout, chV))
+	}
+	return nil, false
+}
+
+// ReceivesEqual checks whether a value is received from the channel ch within timeout and it
+// equals exp, compared as Equal does.
+func ReceivesEqual(t testing.TB, name string, ch interface{}, exp interface{}, timeout time.Duration) bool {
+	t.Helper()
+	return New(t).ReceivesEqual(name, ch, exp, timeout)
+}
+
+// ReceivesEqual is the same as the package-level ReceivesEqual.
+func (a *Assertions) ReceivesEqual(name string, ch interface{}, exp interface{}, timeout time.Duration) bool {
+	a.t.Helper()
+	act, ok := a.Receives(name, ch, timeout)
+	if !ok {
+		return false
+	}
+	m, eq := a.compareOptions(nil).deepValueDiff("value received from "+name, reflect.ValueOf(act), reflect.ValueOf(exp))
+	if !eq {
+		a.fail(m)
+	}
+	return eq
+}
+
+// NotReceives checks whether nothing is received from the channel ch within timeout. A closed
+// channel fails it.
+func NotReceives(t testing.TB, name string, ch interface{}, timeout time.Duration) bool {
+	t.Helper()
+	return New(t).NotReceives(name, ch, timeout)
+}
+
+// NotReceives is the same as the package-level NotReceives.
+func (a *Assertions) NotReceives(name string, ch interface{}, timeout time.Duration) bool {
+	a.t.Helper()
+	chV, err := recvChan(ch)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return false
+	}
+	switch v, r := a.receive(chV, timeout); r {
+	case timedOut:
+		return true
+	case received:
+		a.fail(fmt.Sprintf("%s unexpectedly receives %s %s", name, indentValue(prettyValue(v, false)), chanState(chV)))
+	case closed:
+		a.fail(fmt.Sprintf("%s is unexpectedly closed", name))
+	default:
+		a.fail(a.waitMessage(name, r, timeout, chV))
+	}
+	return false
+}
+
+// Closed checks whether the channel ch is closed within timeout, with no value received before it.
+func Closed(t testing.TB, name string, ch interface{}, timeout time.Duration) bool {
+	t.Helper()
+	return New(t).Closed(name, ch, timeout)
+}
+
+// Closed is the same as the package-level Closed.
+func (a *Assertions) Closed(name string, ch interface{}, timeout time.Duration) bool {
+	a.t.Helper()
+	chV, err := recvChan(ch)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return false
+	}
+	switch v, r := a.receive(chV, timeout); r {
+	case closed:
+		return true
+	case received:
+		a.fail(fmt.Sprintf("%s is expected to be closed, but receives %s %s", name, indentValue(prettyValue(v, false)), chanState(chV)))
+	case timedOut:
+		a.fail(fmt.Sprintf("%s is not closed within %v %s", name, timeout, chanState(chV)))
+	default:
+		a.fail(a.waitMessage(name, r, timeout, chV))
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func ExampleReceivesEqual() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false), PollClock(autoClock()))
+
+	results := make(chan int, 2)
+	results <- 2
+	a.ReceivesEqual("results", results, 1, time.Second)
+	a.ReceivesEqual("results", results, 1, time.Second)
+
+	// OUTPUT:
+	// value received from results is expected to be 1, but got 2
+	// results receives nothing within 1s (len=0, cap=2)
+}
+
+func TestReceives(t *testing.T) {
+	ch := make(chan string)
+	go func() { ch <- "a" }()
+	v, ok := Receives(t, "ch", ch, 10*time.Second)
+	True(t, "ok", ok)
+	Equal(t, "v", v, "a")
+	go func() { ch <- "b" }()
+	True(t, "ReceivesEqual", ReceivesEqual(t, "ch", (<-chan string)(ch), "b", 10*time.Second))
+	True(t, "NotReceives", NotReceives(t, "ch", ch, time.Millisecond))
+	close(ch)
+	True(t, "Closed", Closed(t, "ch", ch, time.Second))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false), PollClock(autoClock()))
+	_, ok = a.Receives("closed", ch, time.Second)
+	False(t, "closed", ok)
+	_, ok = a.Receives("send-only", make(chan<- int), time.Second)
+	False(t, "send-only", ok)
+	full := make(chan []int, 1)
+	full <- []int{1}
+	False(t, "NotReceives", a.NotReceives("full", full, time.Second))
+	False(t, "NotReceives", a.NotReceives("closed", ch, time.Second))
+	full <- []int{2}
+	False(t, "Closed", a.Closed("full", full, time.Second))
+	False(t, "Closed", a.Closed("full", full, time.Second))
+
+	StringEqual(t, "log", "\n"+string(b), `
+closed is unexpectedly closed
+assert: send-only: expecting a receivable channel, but got chan<- int
+full unexpectedly receives []int{1} (len=0, cap=1)
+closed is unexpectedly closed
+full is expected to be closed, but receives []int{2} (len=0, cap=1)
+full is not closed within 1s (len=0, cap=1)
+`)
+}
+
+func TestReceives_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false), PollContext(ctx))
+	_, ok := a.Receives("ch", make(chan int), time.Hour)
+	False(t, "Receives", ok)
+
+	StringEqual(t, "log", "\n"+string(b), `
+ch is not checked, canceled: context canceled (len=0, cap=0)
+`)
+}
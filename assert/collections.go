@@ -0,0 +1,341 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// unwrapInterface returns the dynamic value of an interface value, or an invalid value if it is
+// nil, so that elements of []interface{} compare with values passed as interface{}.
+func unwrapInterface(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func (o *compareOptions) valueEqual(act, exp reflect.Value) bool {
+	_, eq := o.deepValueDiff("", unwrapInterface(act), unwrapInterface(exp))
+	return eq
+}
+
+// containerMessage returns the message of a failure about an element and a container, e.g.
+// "v is expected to contain 1, but got (len=2)[]int{2, 3}".
+func containerMessage(name, expectation string, elem interface{}, container reflect.Value) string {
+	elemMsg := prettyValue(reflect.ValueOf(elem), false)
+	contMsg := valueMessage(container, hasLen(container))
+	msg := fmt.Sprintf("%s is expected to %s %s, but got %s", name, expectation, elemMsg, contMsg)
+	if len(msg) >= 80 || strings.ContainsRune(msg, '\n') {
+		msg = fmt.Sprintf("%s is expected to %s\n  %s\nbut got\n  %s", name, expectation, indentValue(elemMsg), indentValue(contMsg))
+	}
+	return msg
+}
+
+func hasLen(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.Chan, reflect.String:
+		return true
+	}
+	return false
+}
+
+// contains returns whether container, a string, an array, a slice or a map, contains elem, i.e. a
+// substring of a string or an element of an array, slice or map values.
+func (o *compareOptions) contains(container reflect.Value, elem interface{}) (found bool, err error) {
+	switch container.Kind() {
+	case reflect.String:
+		s, ok := elem.(string)
+		if !ok {
+			return false, fmt.Errorf("expecting a string to search in a string, but got %T", elem)
+		}
+		return strings.Contains(container.String(), s), nil
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < container.Len(); i++ {
+			if o.valueEqual(container.Index(i), reflect.ValueOf(elem)) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case reflect.Map:
+		iter := container.MapRange()
+		for iter.Next() {
+			if o.valueEqual(iter.Value(), reflect.ValueOf(elem)) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("expecting a string, an array, a slice or a map, but got %s", typeName(container))
+}
+
+func typeName(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return v.Type().String()
+}
+
+// Contains checks whether container, a string, an array, a slice or a map, contains elem, i.e. a
+// substring of a string, an element of an array or a slice, or a value of a map. Elements are
+// compared as Equal does.
+func Contains(t testing.TB, name string, container, elem interface{}) bool {
+	t.Helper()
+	return New(t).Contains(name, container, elem)
+}
+
+// Contains is the same as the package-level Contains.
+func (a *Assertions) Contains(name string, container, elem interface{}) bool {
+	a.t.Helper()
+	v := reflect.ValueOf(container)
+	found, err := a.compareOptions(nil).contains(v, elem)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return false
+	}
+	if !found {
+		a.fail(containerMessage(name, "contain", elem, v))
+	}
+	return found
+}
+
+// NotContains checks whether container, a string, an array, a slice or a map, does not contain
+// elem. See Contains.
+func NotContains(t testing.TB, name string, container, elem interface{}) bool {
+	t.Helper()
+	return New(t).NotContains(name, container, elem)
+}
+
+// NotContains is the same as the package-level NotContains.
+func (a *Assertions) NotContains(name string, container, elem interface{}) bool {
+	a.t.Helper()
+	v := reflect.ValueOf(container)
+	found, err := a.compareOptions(nil).contains(v, elem)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return false
+	}
+	if found {
+		a.fail(containerMessage(name, "not contain", elem, v))
+	}
+	return !found
+}
+
+// notIn returns the messages of the elements of sub, an array, a slice or a map, which are not in
+// super, whose kind is the same as sub, e.g. "extra 1". Entries of maps are compared by both keys
+// and values.
+func (o *compareOptions) notIn(prefix string, sub, super reflect.Value) (m []string, err error) {
+	if !sub.IsValid() || !super.IsValid() {
+		return nil, fmt.Errorf("expecting arrays, slices or maps, but got %s and %s", typeName(sub), typeName(super))
+	}
+	switch sub.Kind() {
+	case reflect.Array, reflect.Slice:
+		if super.Kind() != reflect.Array && super.Kind() != reflect.Slice {
+			break
+		}
+		for i := 0; i < sub.Len(); i++ {
+			found := false
+			for j := 0; j < super.Len() && !found; j++ {
+				found = o.valueEqual(sub.Index(i), super.Index(j))
+			}
+			if !found {
+				m = append(m, prefix+" "+indentValue(prettyValue(unwrapInterface(sub.Index(i)), false)))
+			}
+		}
+		return m, nil
+	case reflect.Map:
+		if super.Kind() != reflect.Map || sub.Type().Key() != super.Type().Key() {
+			break
+		}
+		for _, k := range sortedKeys(sub) {
+			if v := super.MapIndex(k); !v.IsValid() || !o.valueEqual(sub.MapIndex(k), v) {
+				m = append(m, fmt.Sprintf("%s %s -> %s", prefix, valueMessage(k, false), indentValue(valueMessage(sub.MapIndex(k), false))))
+			}
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("expecting arrays, slices or maps of the same key type, but got %s and %s", sub.Type(), super.Type())
+}
+
+// sortedKeys returns the keys of a map in the order they are printed.
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	msgs := make([]string, len(keys))
+	for i, k := range keys {
+		msgs[i] = valueMessage(k, false)
+	}
+	sort.Sort(keysByMessage{keys: keys, msgs: msgs})
+	return keys
+}
+
+type keysByMessage struct {
+	keys []reflect.Value
+	msgs []string
+}
+
+func (s keysByMessage) Len() int           { return len(s.keys) }
+func (s keysByMessage) Less(i, j int) bool { return s.msgs[i] < s.msgs[j] }
+func (s keysByMessage) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.msgs[i], s.msgs[j] = s.msgs[j], s.msgs[i]
+}
+
+// Subset checks whether every element of act, an array, a slice or a map, is in super. For maps,
+// both keys and values are compared. Elements are compared as Equal does and duplicates are
+// ignored.
+func Subset(t testing.TB, name string, act, super interface{}) bool {
+	t.Helper()
+	return New(t).Subset(name, act, super)
+}
+
+// Subset is the same as the package-level Subset.
+func (a *Assertions) Subset(name string, act, super interface{}) bool {
+	a.t.Helper()
+	superV := reflect.ValueOf(super)
+	m, err := a.compareOptions(nil).notIn("extra", reflect.ValueOf(act), superV)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return false
+	}
+	if len(m) > 0 {
+		a.fail(fmt.Sprintf("%s is not a subset of %s:\n  %s", name, indentValue(valueMessage(superV, false)), strings.Join(m, "\n  ")))
+		return false
+	}
+	return true
+}
+
+// Superset checks whether every element of sub, an array, a slice or a map, is in act. See Subset.
+func Superset(t testing.TB, name string, act, sub interface{}) bool {
+	t.Helper()
+	return New(t).Superset(name, act, sub)
+}
+
+// Superset is the same as the package-level Superset.
+func (a *Assertions) Superset(name string, act, sub interface{}) bool {
+	a.t.Helper()
+	subV := reflect.ValueOf(sub)
+	m, err := a.compareOptions(nil).notIn("missing", subV, reflect.ValueOf(act))
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return false
+	}
+	if len(m) > 0 {
+		a.fail(fmt.Sprintf("%s is not a superset of %s:\n  %s", name, indentValue(valueMessage(subV, false)), strings.Join(m, "\n  ")))
+		return false
+	}
+	return true
+}
+
+// HasKey checks whether the map m has the key.
+func HasKey(t testing.TB, name string, m, key interface{}) bool {
+	t.Helper()
+	return New(t).HasKey(name, m, key)
+}
+
+// HasKey is the same as the package-level HasKey.
+func (a *Assertions) HasKey(name string, m, key interface{}) bool {
+	a.t.Helper()
+	mV := reflect.ValueOf(m)
+	if mV.Kind() != reflect.Map {
+		a.fail(fmt.Sprintf("assert: %s: expecting a map, but got %s", name, typeName(mV)))
+		return false
+	}
+	keyType := mV.Type().Key()
+	keyV := reflect.ValueOf(key)
+	if !keyV.IsValid() {
+		switch keyType.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Chan:
+			keyV = reflect.Zero(keyType)
+		}
+	}
+	if !keyV.IsValid() || !keyV.Type().AssignableTo(keyType) {
+		a.fail(fmt.Sprintf("assert: %s: expecting a key of %v, but got %s", name, keyType, typeName(keyV)))
+		return false
+	}
+	if mV.MapIndex(keyV).IsValid() {
+		return true
+	}
+	a.fail(containerMessage(name, "have key", key, mV))
+	return false
+}
+
+// Len checks whether the length of act, an array, a slice, a map, a channel or a string, is n.
+func Len(t testing.TB, name string, act interface{}, n int) bool {
+	t.Helper()
+	return New(t).Len(name, act, n)
+}
+
+// Len is the same as the package-level Len.
+func (a *Assertions) Len(name string, act interface{}, n int) bool {
+	a.t.Helper()
+	v := reflect.ValueOf(act)
+	if !hasLen(v) {
+		a.fail(fmt.Sprintf("assert: %s: expecting an array, a slice, a map, a channel or a string, but got %s", name, typeName(v)))
+		return false
+	}
+	if v.Len() == n {
+		return true
+	}
+	m := prettyValue(v, false)
+	msg := fmt.Sprintf("%s is expected to have length %d, but got %d: %s", name, n, v.Len(), m)
+	if len(msg) >= 80 || strings.ContainsRune(msg, '\n') {
+		msg = fmt.Sprintf("%s is expected to have length %d, but got %d:\n  %s", name, n, v.Len(), indentValue(m))
+	}
+	a.fail(msg)
+	return false
+}
+
+// isEmpty returns whether v has zero length or is the zero value of its type.
+func isEmpty(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	if hasLen(v) && v.Kind() != reflect.Array {
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// Empty checks whether act is empty, i.e. nil, a zero-length slice, map, channel or string, or the
+// zero value of other types.
+func Empty(t testing.TB, name string, act interface{}) bool {
+	t.Helper()
+	return New(t).Empty(name, act)
+}
+
+// Empty is the same as the package-level Empty.
+func (a *Assertions) Empty(name string, act interface{}) bool {
+	a.t.Helper()
+	v := reflect.ValueOf(act)
+	if isEmpty(v) {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is expected to be empty, but got %s", name, indentValue(valueMessage(v, hasLen(v)))))
+	return false
+}
+
+// NotEmpty checks whether act is not empty. See Empty.
+func NotEmpty(t testing.TB, name string, act interface{}) bool {
+	t.Helper()
+	return New(t).NotEmpty(name, act)
+}
+
+// NotEmpty is the same as the package-level NotEmpty.
+func (a *Assertions) NotEmpty(name string, act interface{}) bool {
+	a.t.Helper()
+	v := reflect.ValueOf(act)
+	if !isEmpty(v) {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is unexpectedly empty: %s", name, valueMessage(v, false)))
+	return false
+}
@@ -0,0 +1,156 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func ExampleContains() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
+
+	a.Contains("ids", []int{1, 2, 3}, 4)
+	a.Subset("tags", []string{"a", "x", "b"}, []string{"a", "b", "c"})
+
+	// OUTPUT:
+	// ids is expected to contain 4, but got (len=3)[]int{1, 2, 3}
+	// tags is not a subset of []string{"a", "b", "c"}:
+	//   extra "x"
+}
+
+func TestContains(t *testing.T) {
+	True(t, "string", Contains(t, "v", "abc", "bc"))
+	True(t, "slice", Contains(t, "v", []interface{}{1, "a", nil}, "a"))
+	True(t, "nil", Contains(t, "v", []interface{}{1, "a", nil}, nil))
+	True(t, "array", Contains(t, "v", [2][]int{{1}, {2}}, []int{2}))
+	True(t, "map", Contains(t, "v", map[string]float64{"a": 1.5}, 1.5))
+	True(t, "tolerance", New(t, Comparison(NumberTolerance(0.1))).Contains("v", []float64{1.0}, 1.05))
+	True(t, "NotContains", NotContains(t, "v", []string{"a"}, "b"))
+	True(t, "NotContains", NotContains(t, "v", "abc", "d"))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "string", a.Contains("s", "abc", "d"))
+	False(t, "map", a.Contains("m", map[string]int{"a": 1}, 2))
+	False(t, "type", a.Contains("s", []int{1}, int64(1)))
+	False(t, "not a container", a.Contains("i", 1, 1))
+	False(t, "not a string", a.Contains("s", "abc", 'a'))
+	False(t, "NotContains", a.NotContains("s", []string{"a", "b"}, "b"))
+	False(t, "long", a.Contains("l", []string{"aaaaaaaaaaaaaaaaaaaa", "bbbbbbbbbbbbbbbbbbbb", "cccccccccccccccccccc"}, "d"))
+
+	StringEqual(t, "log", "\n"+string(b), `
+s is expected to contain "d", but got (len=3)"abc"
+m is expected to contain 2, but got (len=1)map[string]int{"a": 1}
+s is expected to contain 1, but got (len=1)[]int{1}
+assert: i: expecting a string, an array, a slice or a map, but got int
+assert: s: expecting a string to search in a string, but got int32
+s is expected to not contain "b", but got (len=2)[]string{"a", "b"}
+l is expected to contain
+  "d"
+but got
+  (len=3)[]string{
+    "aaaaaaaaaaaaaaaaaaaa",
+    "bbbbbbbbbbbbbbbbbbbb",
+    "cccccccccccccccccccc",
+  }
+`)
+}
+
+func TestSubset(t *testing.T) {
+	True(t, "slice", Subset(t, "v", []int{1, 1, 2}, [3]int{2, 1, 3}))
+	True(t, "empty", Subset(t, "v", []int{}, []int{1}))
+	True(t, "map", Subset(t, "v", map[string]int{"a": 1}, map[string]int{"a": 1, "b": 2}))
+	True(t, "Superset", Superset(t, "v", []string{"a", "b"}, []string{"b"}))
+	True(t, "Superset", Superset(t, "v", map[int]bool{1: true, 2: false}, map[int]bool{2: false}))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "map", a.Subset("m", map[string]int{"a": 1, "b": 3, "c": 3}, map[string]int{"a": 1, "b": 2}))
+	False(t, "Superset", a.Superset("s", []int{1, 2}, []int{3, 2, 4}))
+	False(t, "kinds", a.Subset("s", []int{1}, map[int]int{}))
+	False(t, "nil", a.Superset("s", nil, []int{1}))
+
+	StringEqual(t, "log", "\n"+string(b), `
+m is not a subset of map[string]int{"a": 1, "b": 2}:
+  extra "b" -> 3
+  extra "c" -> 3
+s is not a superset of []int{3, 2, 4}:
+  missing 3
+  missing 4
+assert: s: expecting arrays, slices or maps of the same key type, but got []int and map[int]int
+assert: s: expecting arrays, slices or maps, but got []int and nil
+`)
+}
+
+func TestHasKey(t *testing.T) {
+	True(t, "HasKey", HasKey(t, "v", map[string]int{"a": 1}, "a"))
+	True(t, "interface", HasKey(t, "v", map[interface{}]int{1: 1, nil: 2}, nil))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "missing", a.HasKey("m", map[string]int{"a": 1}, "b"))
+	False(t, "key type", a.HasKey("m", map[string]int{"a": 1}, 1))
+	False(t, "nil", a.HasKey("m", map[string]int{"a": 1}, nil))
+	False(t, "not a map", a.HasKey("m", []int{1}, 0))
+
+	StringEqual(t, "log", "\n"+string(b), `
+m is expected to have key "b", but got (len=1)map[string]int{"a": 1}
+assert: m: expecting a key of string, but got int
+assert: m: expecting a key of string, but got nil
+assert: m: expecting a map, but got []int
+`)
+}
+
+func TestLen(t *testing.T) {
+	True(t, "slice", Len(t, "v", []int{1, 2}, 2))
+	True(t, "string", Len(t, "v", "abc", 3))
+	True(t, "chan", Len(t, "v", make(chan int, 1), 0))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "map", a.Len("m", map[int]int{1: 2}, 2))
+	False(t, "int", a.Len("i", 1, 2))
+
+	StringEqual(t, "log", "\n"+string(b), `
+m is expected to have length 2, but got 1: map[int]int{1: 2}
+assert: i: expecting an array, a slice, a map, a channel or a string, but got int
+`)
+}
+
+func TestEmpty(t *testing.T) {
+	True(t, "nil", Empty(t, "v", nil))
+	True(t, "slice", Empty(t, "v", []int{}))
+	True(t, "map", Empty(t, "v", map[int]int(nil)))
+	True(t, "string", Empty(t, "v", ""))
+	True(t, "zero", Empty(t, "v", struct{ A int }{}))
+	True(t, "array", Empty(t, "v", [2]int{}))
+	True(t, "NotEmpty", NotEmpty(t, "v", []int{0}))
+	True(t, "NotEmpty", NotEmpty(t, "v", 1))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "slice", a.Empty("s", []string{"a"}))
+	False(t, "int", a.Empty("i", 1))
+	False(t, "NotEmpty", a.NotEmpty("s", ""))
+	False(t, "NotEmpty", a.NotEmpty("p", (*int)(nil)))
+
+	StringEqual(t, "log", "\n"+string(b), `
+s is expected to be empty, but got (len=1)[]string{"a"}
+i is expected to be empty, but got 1
+s is unexpectedly empty: ""
+p is unexpectedly empty: (*int)(nil)
+`)
+}
@@ -0,0 +1,190 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// unwrapErrors returns the errors wrapped by err, by either Unwrap() error or Unwrap() []error,
+// e.g. those created by fmt.Errorf with %w and errors.Join.
+func unwrapErrors(err error) []error {
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if e := u.Unwrap(); e != nil {
+			return []error{e}
+		}
+	case interface{ Unwrap() []error }:
+		var errs []error
+		for _, e := range u.Unwrap() {
+			if e != nil {
+				errs = append(errs, e)
+			}
+		}
+		return errs
+	}
+	return nil
+}
+
+// walkErrors calls f with err and the errors wrapped by it, recursively, in pre-order, until f
+// returns false. Returns whether f returned false.
+func walkErrors(err error, f func(error) bool) bool {
+	if !f(err) {
+		return true
+	}
+	for _, e := range unwrapErrors(err) {
+		if walkErrors(e, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorChain returns the tree of err and the errors wrapped by it, one per line with its type.
+// Wrapped errors are indented under the wrapping one.
+func errorChain(err error) string {
+	var lines []string
+	var walk func(err error, indent string)
+	walk = func(err error, indent string) {
+		lines = append(lines, fmt.Sprintf("%s%T: %q", indent, err, err.Error()))
+		for _, e := range unwrapErrors(err) {
+			walk(e, indent+"  ")
+		}
+	}
+	walk(err, "")
+	return strings.Join(lines, "\n")
+}
+
+// errorMessage returns the message of a failure about err, with the error chain of err.
+func errorMessage(msg string, err error) string {
+	if err == nil {
+		return msg + " nil"
+	}
+	return fmt.Sprintf("%s\n  %s", msg, indentValue(errorChain(err)))
+}
+
+// isError returns whether err or an error wrapped by it matches target, like errors.Is. Both
+// Unwrap() error and Unwrap() []error are followed.
+func isError(err, target error) bool {
+	canCompare := target == nil || reflect.TypeOf(target).Comparable()
+	return walkErrors(err, func(e error) bool {
+		if canCompare && e == target {
+			return false
+		}
+		if x, ok := e.(interface{ Is(error) bool }); ok && x.Is(target) {
+			return false
+		}
+		return true
+	})
+}
+
+// asError finds the first error in the tree of err that matches target, like errors.As, and sets
+// target to it. target must be a non-nil pointer to an interface or a type implementing error.
+func asError(err error, target interface{}) (found bool, e error) {
+	v := reflect.ValueOf(target)
+	if !v.IsValid() || v.Kind() != reflect.Ptr || v.IsNil() {
+		return false, fmt.Errorf("target must be a non-nil pointer, but got %s", typeName(v))
+	}
+	typ := v.Type().Elem()
+	if typ.Kind() != reflect.Interface && !typ.Implements(errorType) {
+		return false, fmt.Errorf("*target must be an interface or implement error, but got %v", typ)
+	}
+	if err == nil {
+		return false, nil
+	}
+	walkErrors(err, func(e error) bool {
+		if reflect.TypeOf(e).AssignableTo(typ) {
+			v.Elem().Set(reflect.ValueOf(e))
+			found = true
+		} else if x, ok := e.(interface{ As(interface{}) bool }); ok && x.As(target) {
+			found = true
+		}
+		return !found
+	})
+	return found, nil
+}
+
+// ErrorIs checks whether err or an error wrapped by it, including those in errors.Join trees,
+// matches target, like errors.Is.
+func ErrorIs(t testing.TB, err, target error) bool {
+	t.Helper()
+	return New(t).ErrorIs(err, target)
+}
+
+// ErrorIs is the same as the package-level ErrorIs.
+func (a *Assertions) ErrorIs(err, target error) bool {
+	a.t.Helper()
+	if err != nil && isError(err, target) {
+		return true
+	}
+	a.fail(errorMessage(fmt.Sprintf("Expecting error matching %T(%q), but got", target, fmt.Sprint(target)), err))
+	return false
+}
+
+// ErrorAs checks whether err or an error wrapped by it, including those in errors.Join trees,
+// matches target, like errors.As, and sets target to it if so.
+func ErrorAs(t testing.TB, err error, target interface{}) bool {
+	t.Helper()
+	return New(t).ErrorAs(err, target)
+}
+
+// ErrorAs is the same as the package-level ErrorAs.
+func (a *Assertions) ErrorAs(err error, target interface{}) bool {
+	a.t.Helper()
+	found, e := asError(err, target)
+	if e != nil {
+		a.fail(fmt.Sprintf("assert: %v", e))
+		return false
+	}
+	if found {
+		return true
+	}
+	a.fail(errorMessage(fmt.Sprintf("Expecting error of type %v, but got", reflect.TypeOf(target).Elem()), err))
+	return false
+}
+
+// ErrorContains checks whether err is not nil and its message contains substr.
+func ErrorContains(t testing.TB, err error, substr string) bool {
+	t.Helper()
+	return New(t).ErrorContains(err, substr)
+}
+
+// ErrorContains is the same as the package-level ErrorContains.
+func (a *Assertions) ErrorContains(err error, substr string) bool {
+	a.t.Helper()
+	if err != nil && strings.Contains(err.Error(), substr) {
+		return true
+	}
+	a.fail(errorMessage(fmt.Sprintf("Expecting error containing %q, but got", substr), err))
+	return false
+}
+
+// ErrorMatches checks whether err is not nil and its message matches the regular expression
+// pattern.
+func ErrorMatches(t testing.TB, err error, pattern string) bool {
+	t.Helper()
+	return New(t).ErrorMatches(err, pattern)
+}
+
+// ErrorMatches is the same as the package-level ErrorMatches.
+func (a *Assertions) ErrorMatches(err error, pattern string) bool {
+	a.t.Helper()
+	re, e := regexp.Compile(pattern)
+	if e != nil {
+		a.fail(fmt.Sprintf("assert: invalid pattern: %v", e))
+		return false
+	}
+	if err != nil && re.MatchString(err.Error()) {
+		return true
+	}
+	a.fail(errorMessage(fmt.Sprintf("Expecting error matching /%s/, but got", pattern), err))
+	return false
+}
@@ -0,0 +1,117 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+// multiError is the same as what errors.Join returns, which is not available in old Go versions.
+type multiError []error
+
+func (e multiError) Error() string {
+	return fmt.Sprint([]error(e))
+}
+
+func (e multiError) Unwrap() []error {
+	return e
+}
+
+func ExampleErrorIs() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
+
+	a.ErrorIs(fmt.Errorf("read config: %w", io.ErrUnexpectedEOF), io.EOF)
+
+	// OUTPUT:
+	// Expecting error matching *errors.errorString("EOF"), but got
+	//   *fmt.wrapError: "read config: unexpected EOF"
+	//     *errors.errorString: "unexpected EOF"
+}
+
+func TestErrorIs(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", multiError{errors.New("a"), fmt.Errorf("b: %w", io.EOF)})
+	True(t, "ErrorIs", ErrorIs(t, err, io.EOF))
+	True(t, "ErrorIs", ErrorIs(t, io.EOF, io.EOF))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "ErrorIs", a.ErrorIs(err, io.ErrClosedPipe))
+	False(t, "nil", a.ErrorIs(nil, io.EOF))
+
+	StringEqual(t, "log", "\n"+string(b), `
+Expecting error matching *errors.errorString("io: read/write on closed pipe"), but got
+  *fmt.wrapError: "wrapped: [a b: EOF]"
+    assert.multiError: "[a b: EOF]"
+      *errors.errorString: "a"
+      *fmt.wrapError: "b: EOF"
+        *errors.errorString: "EOF"
+Expecting error matching *errors.errorString("EOF"), but got nil
+`)
+}
+
+func TestErrorAs(t *testing.T) {
+	err := multiError{io.EOF, fmt.Errorf("wrapped: %w", &codeError{code: 404})}
+	var ce *codeError
+	True(t, "ErrorAs", ErrorAs(t, err, &ce))
+	Equal(t, "ce", ce, &codeError{code: 404})
+	var ie interface{ Unwrap() []error }
+	True(t, "ErrorAs", ErrorAs(t, err, &ie))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "ErrorAs", a.ErrorAs(io.EOF, &ce))
+	False(t, "non-pointer", a.ErrorAs(io.EOF, (*codeError)(nil)))
+	False(t, "non-error", a.ErrorAs(io.EOF, new(int)))
+	False(t, "nil", a.ErrorAs(nil, &ce))
+
+	StringEqual(t, "log", "\n"+string(b), `
+Expecting error of type *assert.codeError, but got
+  *errors.errorString: "EOF"
+assert: target must be a non-nil pointer, but got *assert.codeError
+assert: *target must be an interface or implement error, but got int
+Expecting error of type *assert.codeError, but got nil
+`)
+}
+
+func TestErrorContains(t *testing.T) {
+	True(t, "ErrorContains", ErrorContains(t, errors.New("not found"), "found"))
+	True(t, "ErrorMatches", ErrorMatches(t, errors.New("code 404"), `^code \d+$`))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "ErrorContains", a.ErrorContains(errors.New("not found"), "denied"))
+	False(t, "ErrorContains", a.ErrorContains(nil, ""))
+	False(t, "ErrorMatches", a.ErrorMatches(&codeError{code: 1}, `^code \d\d$`))
+	False(t, "ErrorMatches", a.ErrorMatches(io.EOF, `(`))
+
+	StringEqual(t, "log", "\n"+string(b), `
+Expecting error containing "denied", but got
+  *errors.errorString: "not found"
+Expecting error containing "", but got nil
+Expecting error matching /^code \d\d$/, but got
+  *assert.codeError: "code 1"
+assert: invalid pattern: error parsing regexp: missing closing ): `+"`(`"+`
+`)
+}
@@ -0,0 +1,172 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var boolType = reflect.TypeOf(false)
+
+// condition converts cond, a func() bool, a func() error or a func() (V, bool), into a func
+// returning whether it holds and the message of the observed value, "" for a func() bool.
+func condition(cond interface{}) (func() (holds bool, observed string), error) {
+	v := reflect.ValueOf(cond)
+	if v.Kind() == reflect.Func && !v.IsNil() && v.Type().NumIn() == 0 {
+		switch t := v.Type(); {
+		case t.NumOut() == 1 && t.Out(0) == boolType:
+			return func() (bool, string) {
+				return v.Call(nil)[0].Bool(), ""
+			}, nil
+		case t.NumOut() == 1 && t.Out(0) == errorType:
+			return func() (bool, string) {
+				err, _ := v.Call(nil)[0].Interface().(error)
+				if err == nil {
+					return true, "nil"
+				}
+				return false, indentValue(errorChain(err))
+			}, nil
+		case t.NumOut() == 2 && t.Out(1) == boolType:
+			return func() (bool, string) {
+				out := v.Call(nil)
+				return out[1].Bool(), indentValue(prettyValue(out[0], false))
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("cond must be a func() bool, a func() error or a func() (V, bool), but got %s", typeName(v))
+}
+
+// pollResult is the outcome of polling a condition.
+type pollResult struct {
+	// Whether polling stopped because the condition became stopWhen.
+	stopped  bool
+	attempts int
+	elapsed  time.Duration
+	observed string
+	// The error of the context if it was canceled.
+	err error
+}
+
+func (r *pollResult) String() string {
+	s := fmt.Sprintf("after %d attempt(s) in %v", r.attempts, r.elapsed)
+	if r.err != nil {
+		s = fmt.Sprintf("%s, canceled: %v", s, r.err)
+	}
+	if r.observed != "" {
+		s += ", last observed: " + r.observed
+	}
+	return s
+}
+
+// poll checks cond every interval until it becomes stopWhen, timeout passes, or the context of a is
+// canceled.
+func (a *Assertions) poll(cond func() (bool, string), timeout, interval time.Duration, stopWhen bool) pollResult {
+	clock, ctx := a.cfg.clock, a.cfg.pollCtx
+	start := clock.Now()
+	var r pollResult
+	for {
+		var holds bool
+		holds, r.observed = cond()
+		r.attempts++
+		r.elapsed = clock.Now().Sub(start)
+		if holds == stopWhen {
+			r.stopped = true
+			return r
+		}
+		if r.elapsed >= timeout {
+			return r
+		}
+		wait := interval
+		if remaining := timeout - r.elapsed; remaining < wait {
+			wait = remaining
+		}
+		select {
+		case <-ctx.Done():
+			r.err = ctx.Err()
+			return r
+		case <-clock.After(wait):
+		}
+	}
+}
+
+// Eventually checks whether cond holds within timeout, checking it every interval. cond is a
+// func() bool, a func() error, which holds if it returns nil, or a func() (V, bool), whose V is
+// reported as the last observed value on failure. See also PollClock and PollContext.
+func Eventually(t testing.TB, name string, cond interface{}, timeout, interval time.Duration) bool {
+	t.Helper()
+	return New(t).Eventually(name, cond, timeout, interval)
+}
+
+// Eventually is the same as the package-level Eventually.
+func (a *Assertions) Eventually(name string, cond interface{}, timeout, interval time.Duration) bool {
+	a.t.Helper()
+	f, err := condition(cond)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %v", err))
+		return false
+	}
+	r := a.poll(f, timeout, interval, true)
+	if !r.stopped {
+		a.fail(fmt.Sprintf("%s is not satisfied within %v, %s", name, timeout, &r))
+	}
+	return r.stopped
+}
+
+// Never checks whether cond never holds during timeout, checking it every interval. See Eventually
+// for cond.
+func Never(t testing.TB, name string, cond interface{}, timeout, interval time.Duration) bool {
+	t.Helper()
+	return New(t).Never(name, cond, timeout, interval)
+}
+
+// Never is the same as the package-level Never.
+func (a *Assertions) Never(name string, cond interface{}, timeout, interval time.Duration) bool {
+	a.t.Helper()
+	f, err := condition(cond)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %v", err))
+		return false
+	}
+	r := a.poll(f, timeout, interval, true)
+	switch {
+	case r.stopped:
+		a.fail(fmt.Sprintf("%s is unexpectedly satisfied %s", name, &r))
+	case r.err != nil:
+		a.fail(fmt.Sprintf("%s is not checked for %v, %s", name, timeout, &r))
+	default:
+		return true
+	}
+	return false
+}
+
+// Consistently checks whether cond keeps holding during timeout, checking it every interval. See
+// Eventually for cond.
+func Consistently(t testing.TB, name string, cond interface{}, timeout, interval time.Duration) bool {
+	t.Helper()
+	return New(t).Consistently(name, cond, timeout, interval)
+}
+
+// Consistently is the same as the package-level Consistently.
+func (a *Assertions) Consistently(name string, cond interface{}, timeout, interval time.Duration) bool {
+	a.t.Helper()
+	f, err := condition(cond)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %v", err))
+		return false
+	}
+	r := a.poll(f, timeout, interval, false)
+	switch {
+	case r.stopped:
+		a.fail(fmt.Sprintf("%s is unexpectedly not satisfied %s", name, &r))
+	case r.err != nil:
+		a.fail(fmt.Sprintf("%s is not checked for %v, %s", name, timeout, &r))
+	default:
+		return true
+	}
+	return false
+}
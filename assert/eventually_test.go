@@ -0,0 +1,127 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+	"github.com/golangplus/testing/clock"
+)
+
+// autoClock returns a clock.Fake which advances by itself, so polling finishes immediately.
+func autoClock() *clock.Fake {
+	c := clock.NewFake(time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC))
+	c.AutoAdvance = true
+	return c
+}
+
+func ExampleEventually() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false), PollClock(autoClock()))
+
+	queued := 0
+	a.Eventually("queue drained", func() (int, bool) {
+		queued++
+		return queued, queued == 0
+	}, time.Second, 300*time.Millisecond)
+
+	// OUTPUT:
+	// queue drained is not satisfied within 1s, after 5 attempt(s) in 1s, last observed: 5
+}
+
+func TestEventually(t *testing.T) {
+	clock := autoClock()
+	start := clock.Now()
+	n := 0
+	True(t, "Eventually", New(t, PollClock(clock)).Eventually("v", func() bool {
+		n++
+		return n == 3
+	}, time.Second, 100*time.Millisecond))
+	Equal(t, "elapsed", clock.Now().Sub(start), 200*time.Millisecond)
+	True(t, "Eventually", Eventually(t, "v", func() error { return nil }, time.Second, time.Millisecond))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false), PollClock(autoClock()))
+	False(t, "error", a.Eventually("v", func() error { return errors.New("not ready") }, time.Second, time.Second))
+	False(t, "bool", a.Eventually("v", func() bool { return false }, 0, time.Second))
+	False(t, "cond", a.Eventually("v", func(int) bool { return true }, time.Second, time.Second))
+
+	StringEqual(t, "log", "\n"+string(b), `
+v is not satisfied within 1s, after 2 attempt(s) in 1s, last observed: *errors.errorString: "not ready"
+v is not satisfied within 0s, after 1 attempt(s) in 0s
+assert: cond must be a func() bool, a func() error or a func() (V, bool), but got func(int) bool
+`)
+}
+
+func TestEventually_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false), PollContext(ctx), PollClock(clock.NewFake(time.Time{})))
+	False(t, "Eventually", a.Eventually("v", func() bool { return false }, time.Hour, time.Minute))
+	False(t, "Never", a.Never("v", func() bool { return false }, time.Hour, time.Minute))
+	False(t, "Consistently", a.Consistently("v", func() bool { return true }, time.Hour, time.Minute))
+
+	StringEqual(t, "log", "\n"+string(b), `
+v is not satisfied within 1h0m0s, after 1 attempt(s) in 0s, canceled: context canceled
+v is not checked for 1h0m0s, after 1 attempt(s) in 0s, canceled: context canceled
+v is not checked for 1h0m0s, after 1 attempt(s) in 0s, canceled: context canceled
+`)
+}
+
+func TestNever(t *testing.T) {
+	True(t, "Never", New(t, PollClock(autoClock())).Never("v", func() bool { return false }, time.Second, time.Millisecond))
+	True(t, "Consistently", New(t, PollClock(autoClock())).Consistently("v", func() (string, bool) { return "ok", true }, time.Second, time.Millisecond))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false), PollClock(autoClock()))
+	n := 0
+	False(t, "Never", a.Never("v", func() (int, bool) {
+		n++
+		return n, n > 2
+	}, time.Second, 100*time.Millisecond))
+	False(t, "Consistently", a.Consistently("v", func() error { return errors.New("down") }, time.Second, time.Second))
+
+	StringEqual(t, "log", "\n"+string(b), `
+v is unexpectedly satisfied after 3 attempt(s) in 200ms, last observed: 3
+v is unexpectedly not satisfied after 1 attempt(s) in 0s, last observed: *errors.errorString: "down"
+`)
+}
+
+func TestEventually_SystemClock(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(done)
+	}()
+	True(t, "Eventually", Eventually(t, "done", func() bool {
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	}, 10*time.Second, time.Millisecond))
+}
+
+func TestPollOptions_Nil(t *testing.T) {
+	Panic(t, "PollClock", func() {
+		New(t, PollClock(nil))
+	})
+	Panic(t, "PollContext", func() {
+		New(t, PollContext(nil))
+	})
+}
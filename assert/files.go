@@ -0,0 +1,245 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// FileExists checks whether path exists and is not a directory.
+func FileExists(t testing.TB, name, path string) bool {
+	t.Helper()
+	return New(t).FileExists(name, path)
+}
+
+// FileExists is the same as the package-level FileExists.
+func (a *Assertions) FileExists(name, path string) bool {
+	a.t.Helper()
+	fi, err := os.Stat(path)
+	if err != nil {
+		a.fail(fmt.Sprintf("%s is expected to be an existing file, but got %v", name, err))
+		return false
+	}
+	if fi.IsDir() {
+		a.fail(fmt.Sprintf("%s is expected to be a file, but %s is a directory", name, path))
+		return false
+	}
+	return true
+}
+
+// DirExists checks whether path exists and is a directory.
+func DirExists(t testing.TB, name, path string) bool {
+	t.Helper()
+	return New(t).DirExists(name, path)
+}
+
+// DirExists is the same as the package-level DirExists.
+func (a *Assertions) DirExists(name, path string) bool {
+	a.t.Helper()
+	fi, err := os.Stat(path)
+	if err != nil {
+		a.fail(fmt.Sprintf("%s is expected to be an existing directory, but got %v", name, err))
+		return false
+	}
+	if !fi.IsDir() {
+		a.fail(fmt.Sprintf("%s is expected to be a directory, but %s is a %v file", name, path, fi.Mode()))
+		return false
+	}
+	return true
+}
+
+// FileContent checks whether the content of the file at path is exp, compared as StringEqual
+// does, i.e. multi-line contents are diffed by lines.
+func FileContent(t testing.TB, name, path, exp string) bool {
+	t.Helper()
+	return New(t).FileContent(name, path, exp)
+}
+
+// FileContent is the same as the package-level FileContent.
+func (a *Assertions) FileContent(name, path, exp string) bool {
+	a.t.Helper()
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		a.fail(fmt.Sprintf("%s is expected to be readable, but got %v", name, err))
+		return false
+	}
+	return a.StringEqual(name, string(data), exp)
+}
+
+// treeEntry is a file or a directory in a tree.
+type treeEntry struct {
+	mode    fs.FileMode
+	content []byte
+	// The target of a symbolic link, if the file system supports reading it.
+	link     string
+	readLink bool
+}
+
+// readLinkFS is a file system supporting reading symbolic links, the same as fs.ReadLinkFS of
+// newer Go versions.
+type readLinkFS interface {
+	fs.FS
+	ReadLink(name string) (string, error)
+}
+
+// dirFS is os.DirFS(dir) reading symbolic links by os.Readlink.
+type dirFS struct {
+	fs.FS
+	dir string
+}
+
+func (d dirFS) ReadLink(name string) (string, error) {
+	return os.Readlink(filepath.Join(d.dir, filepath.FromSlash(name)))
+}
+
+// readTree returns the entries of fsys by their slash-separated paths, with a trailing "/" for
+// directories. The root itself is not included.
+func readTree(fsys fs.FS) (map[string]treeEntry, error) {
+	tree := make(map[string]treeEntry)
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == "." {
+			return err
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		e := treeEntry{mode: fi.Mode()}
+		if d.IsDir() {
+			path += "/"
+		} else if e.mode.IsRegular() {
+			if e.content, err = fs.ReadFile(fsys, path); err != nil {
+				return err
+			}
+		} else if lfs, ok := fsys.(readLinkFS); ok && e.mode&fs.ModeSymlink != 0 {
+			if e.link, err = lfs.ReadLink(path); err != nil {
+				return err
+			}
+			e.readLink = true
+		}
+		tree[path] = e
+		return nil
+	})
+	return tree, err
+}
+
+// isText returns whether content is shown by lines in a diff.
+func isText(content []byte) bool {
+	return utf8.Valid(content) && bytes.IndexByte(content, 0) < 0
+}
+
+// underAny returns whether path is under any of the directories in dirs.
+func underAny(path string, dirs []string) bool {
+	for _, d := range dirs {
+		if strings.HasSuffix(d, "/") && strings.HasPrefix(path, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileTreeDiff returns the lines of the differences between two trees, or nil if they are equal.
+// The contents of an added or removed directory are not listed.
+func fileTreeDiff(act, exp map[string]treeEntry) []string {
+	var paths []string
+	for p := range act {
+		paths = append(paths, p)
+	}
+	for p := range exp {
+		if _, ok := act[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	var lines, added, removed []string
+	for _, p := range paths {
+		actE, inAct := act[p]
+		expE, inExp := exp[p]
+		switch {
+		case !inExp:
+			if !underAny(p, added) {
+				added = append(added, p)
+				lines = append(lines, "  added: "+p)
+			}
+		case !inAct:
+			if !underAny(p, removed) {
+				removed = append(removed, p)
+				lines = append(lines, "  removed: "+p)
+			}
+		case actE.mode != expE.mode:
+			lines = append(lines, fmt.Sprintf("  mode changed: %s: expected %v, but got %v", p, expE.mode, actE.mode))
+		case actE.readLink && expE.readLink && actE.link != expE.link:
+			lines = append(lines, fmt.Sprintf("  link changed: %s: expected -> %s, but got -> %s", p, expE.link, actE.link))
+		case !bytes.Equal(actE.content, expE.content):
+			lines = append(lines, "  content changed: "+p)
+			if !isText(actE.content) || !isText(expE.content) {
+				lines = append(lines, fmt.Sprintf("    binary, expected %d bytes, but got %d bytes", len(expE.content), len(actE.content)))
+				continue
+			}
+			m := linesDiff(p, strings.Split(string(actE.content), "\n"), strings.Split(string(expE.content), "\n"))
+			lines = append(lines, "    "+strings.Replace(m, "\n", "\n    ", -1))
+		}
+	}
+	return lines
+}
+
+func (a *Assertions) treeEqual(name string, act, exp fs.FS) bool {
+	a.t.Helper()
+	actT, err := readTree(act)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: reading actual tree: %v", name, err))
+		return false
+	}
+	expT, err := readTree(exp)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: reading expected tree: %v", name, err))
+		return false
+	}
+	lines := fileTreeDiff(actT, expT)
+	if len(lines) == 0 {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is not the same as the expected tree:\n%s", name, strings.Join(lines, "\n")))
+	return false
+}
+
+// DirEqual checks whether the directory trees under act and exp are the same, including the names,
+// the modes and the contents of the files and directories, and the targets of symbolic links. On
+// failure, the added, removed and changed entries are reported, with line diffs of changed text
+// files.
+func DirEqual(t testing.TB, name, act, exp string) bool {
+	t.Helper()
+	return New(t).DirEqual(name, act, exp)
+}
+
+// DirEqual is the same as the package-level DirEqual.
+func (a *Assertions) DirEqual(name, act, exp string) bool {
+	a.t.Helper()
+	return a.treeEqual(name, dirFS{FS: os.DirFS(act), dir: act}, dirFS{FS: os.DirFS(exp), dir: exp})
+}
+
+// FSEqual checks whether the trees of the file systems act and exp are the same. See DirEqual. The
+// targets of symbolic links are compared if both file systems have a ReadLink(name string)
+// (string, error) method, as fs.ReadLinkFS does.
+func FSEqual(t testing.TB, name string, act, exp fs.FS) bool {
+	t.Helper()
+	return New(t).FSEqual(name, act, exp)
+}
+
+// FSEqual is the same as the package-level FSEqual.
+func (a *Assertions) FSEqual(name string, act, exp fs.FS) bool {
+	a.t.Helper()
+	return a.treeEqual(name, act, exp)
+}
@@ -0,0 +1,139 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+// writeFiles creates the files under dir, creating parent directories as needed.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for p, content := range files {
+		p = filepath.Join(dir, filepath.FromSlash(p))
+		NoErrorOrDie(t, os.MkdirAll(filepath.Dir(p), 0755))
+		NoErrorOrDie(t, ioutil.WriteFile(p, []byte(content), 0644))
+		// Not affected by the umask.
+		NoErrorOrDie(t, os.Chmod(p, 0644))
+	}
+}
+
+func TestFileExists(t *testing.T) {
+	dir, err := ioutil.TempDir("", "files")
+	NoErrorOrDie(t, err)
+	defer os.RemoveAll(dir)
+	writeFiles(t, dir, map[string]string{"a.txt": "a\nb\n"})
+	file := filepath.Join(dir, "a.txt")
+
+	True(t, "FileExists", FileExists(t, "a", file))
+	True(t, "DirExists", DirExists(t, "dir", dir))
+	True(t, "FileContent", FileContent(t, "a", file, "a\nb\n"))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "FileExists", a.FileExists("dir", dir))
+	False(t, "DirExists", a.DirExists("a", file))
+	False(t, "FileContent", a.FileContent("a", file, "a\nc\n"))
+
+	StringEqual(t, "log", "\n"+string(b), `
+dir is expected to be a file, but `+dir+` is a directory
+a is expected to be a directory, but `+file+` is a -rw-r--r-- file
+Unexpected a: both 3 lines
+  Difference(expected ---  actual +++)
+    ---   2: "c"
+    +++   2: "b"
+`)
+
+	b = nil
+	False(t, "FileExists", a.FileExists("none", filepath.Join(dir, "none")))
+	Matches(t, "log", string(b), `^none is expected to be an existing file, but got stat .*none: no such file or directory\n$`)
+}
+
+func TestDirEqual(t *testing.T) {
+	dir, err := ioutil.TempDir("", "files")
+	NoErrorOrDie(t, err)
+	defer os.RemoveAll(dir)
+	act, exp := filepath.Join(dir, "act"), filepath.Join(dir, "exp")
+	files := map[string]string{
+		"a.txt":     "a\nb\nc\n",
+		"sub/b.txt": "b\n",
+	}
+	writeFiles(t, act, files)
+	writeFiles(t, exp, files)
+	True(t, "DirEqual", DirEqual(t, "out", act, exp))
+
+	writeFiles(t, act, map[string]string{
+		"a.txt":         "a\nB\nc\n",
+		"new/x.txt":     "x",
+		"new/sub/y.txt": "y",
+		"bin":           "\x00\x01",
+	})
+	writeFiles(t, exp, map[string]string{
+		"bin":       "\x00",
+		"old.txt":   "old",
+		"sub/c.txt": "c",
+	})
+	NoErrorOrDie(t, os.Chmod(filepath.Join(act, "sub", "b.txt"), 0755))
+	// Links to different targets, whose modes are the same.
+	NoErrorOrDie(t, os.Symlink("a.txt", filepath.Join(act, "link")))
+	NoErrorOrDie(t, os.Symlink("sub/b.txt", filepath.Join(exp, "link")))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	False(t, "DirEqual", New(bt, FilePosition(false)).DirEqual("out", act, exp))
+	StringEqual(t, "log", "\n"+string(b), `
+out is not the same as the expected tree:
+  content changed: a.txt
+    Unexpected a.txt: both 4 lines
+      Difference(expected ---  actual +++)
+        ---   2: "b"
+        +++   2: "B"
+  content changed: bin
+    binary, expected 1 bytes, but got 2 bytes
+  link changed: link: expected -> sub/b.txt, but got -> a.txt
+  added: new/
+  removed: old.txt
+  mode changed: sub/b.txt: expected -rw-r--r--, but got -rwxr-xr-x
+  removed: sub/c.txt
+`)
+
+	b = nil
+	False(t, "DirEqual", New(bt, FilePosition(false)).DirEqual("out", act, filepath.Join(dir, "none")))
+	Matches(t, "log", string(b), `^assert: out: reading expected tree: .*no such file or directory\n$`)
+}
+
+func TestFSEqual(t *testing.T) {
+	exp := fstest.MapFS{
+		"a.txt":     {Data: []byte("a\n")},
+		"dir/b.txt": {Data: []byte("b\n")},
+	}
+	True(t, "FSEqual", FSEqual(t, "fs", fstest.MapFS{
+		"a.txt":     {Data: []byte("a\n")},
+		"dir/b.txt": {Data: []byte("b\n")},
+	}, exp))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "FSEqual", a.FSEqual("fs", fstest.MapFS{
+		"a.txt":   {Data: []byte("a\n")},
+		"dir":     {Mode: os.ModeDir | 0755},
+		"c/d.txt": {Data: []byte("d\n")},
+	}, exp))
+
+	StringEqual(t, "log", "\n"+string(b), `
+fs is not the same as the expected tree:
+  added: c/
+  mode changed: dir/: expected dr-xr-xr-x, but got drwxr-xr-x
+  removed: dir/b.txt
+`)
+}
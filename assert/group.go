@@ -0,0 +1,75 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Group is an Assertions collecting the failures of its assertions, which are reported together,
+// as one block with a count, by Done. It is safe to use in multiple goroutines.
+type Group struct {
+	*Assertions
+
+	name string
+
+	mu       sync.Mutex
+	failures []string
+}
+
+// NewGroup returns a Group on t. Done should be called, typically deferred, to report the failures.
+// If Fatal(true) is specified, the test is stopped by Done if any assertion failed. Failures not
+// reported by Done are reported, without stopping the test, when it finishes, by t.Cleanup.
+func NewGroup(t testing.TB, name string, opts ...Option) *Group {
+	g := &Group{Assertions: New(t, opts...), name: name}
+	g.cfg.group = g
+	t.Cleanup(func() {
+		g.report(false)
+	})
+	return g
+}
+
+func (g *Group) add(msg string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failures = append(g.failures, strings.TrimPrefix(msg, "\n"))
+}
+
+// Done reports the failures collected so far, if any, and returns whether no assertion failed.
+// Assertions of g can still be used after it and will be reported by the next Done.
+func (g *Group) Done() bool {
+	g.t.Helper()
+	return g.report(g.cfg.fatal)
+}
+
+// report reports the collected failures and returns whether there is none. The test is stopped if
+// there is any and fatal is true.
+func (g *Group) report(fatal bool) bool {
+	g.t.Helper()
+	g.mu.Lock()
+	failures := g.failures
+	g.failures = nil
+	g.mu.Unlock()
+
+	if len(failures) == 0 {
+		return true
+	}
+	// The failures are already formatted with the configuration of g.
+	a := &Assertions{t: g.t, cfg: g.cfg}
+	a.cfg.group, a.cfg.context, a.cfg.verbosity, a.cfg.color = nil, nil, Detailed, false
+	msg := fmt.Sprintf("%s: %d assertion(s) failed:", g.name, len(failures))
+	for _, f := range failures {
+		msg += "\n  " + indentValue(f)
+	}
+	if fatal {
+		a.failNow(msg)
+	} else {
+		a.fail(msg)
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func ExampleGroup() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. NewGroup(t, ...), in normal testing instead.
+	g := NewGroup(&testingp.WriterTB{Writer: os.Stdout}, "resp", FilePosition(false))
+	defer g.Done()
+
+	g.Equal("status", 404, 200)
+	g.True("cached", true)
+	g.StringEqual("body", "a\nb", "a\nc")
+
+	// OUTPUT:
+	// resp: 2 assertion(s) failed:
+	//   status is expected to be 200, but got 404
+	//   Unexpected body: both 2 lines
+	//     Difference(expected ---  actual +++)
+	//       ---   2: "c"
+	//       +++   2: "b"
+}
+
+func TestGroup(t *testing.T) {
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+
+	g := NewGroup(bt, "g", FilePaths(BasePath))
+	True(t, "Done", g.Done())
+	g.True("a", false)
+	g.With("case", 1).NoError(errors.New("failed"))
+	line := 43 // the line number of the last line
+	False(t, "failed before Done", bt.Failed())
+	False(t, "Done", g.Done())
+	True(t, "failed", bt.Failed())
+	True(t, "Done again", g.Done())
+
+	StringEqual(t, "log", "\n"+string(b), fmt.Sprintf(`
+
+group_test.go:%d: g: 2 assertion(s) failed:
+  group_test.go:%d: a unexpectedly got false
+  group_test.go:%d: failed
+    *errors.errorString: "failed"
+    case: 1
+`, line+3, line-1, line))
+}
+
+func TestGroup_Fatal(t *testing.T) {
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+
+	g := NewGroup(bt, "g", FilePosition(false), Fatal(true))
+	True(t, "Done", g.Done())
+	g.False("a", true)
+	True(t, "panic", Panic(t, "Done", func() {
+		g.Done()
+	}))
+	g.True("b", false)
+	True(t, "panic", Panic(t, "ShouldOrDie", func() {
+		g.ShouldOrDie(false, "c")
+	}))
+
+	StringEqual(t, "log", "\n"+string(b), `
+g: 1 assertion(s) failed:
+  a unexpectedly got true
+g: 2 assertion(s) failed:
+  b unexpectedly got false
+  c
+`)
+}
+
+func TestGroup_Cleanup(t *testing.T) {
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+
+	g := NewGroup(bt, "g", FilePosition(false))
+	g.True("a", false)
+	False(t, "Done", g.Done())
+	bt.RunCleanups()
+	g = NewGroup(bt, "h", FilePosition(false))
+	g.True("b", false)
+	bt.RunCleanups()
+
+	StringEqual(t, "log", "\n"+string(b), `
+g: 1 assertion(s) failed:
+  a unexpectedly got false
+h: 1 assertion(s) failed:
+  b unexpectedly got false
+`)
+}
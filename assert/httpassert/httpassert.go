@@ -0,0 +1,192 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package httpassert provides assertions of HTTP responses, e.g. those of handlers tested with
+net/http/httptest:
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	resp := rec.Result()
+	httpassert.Status(t, "resp", resp, http.StatusOK)
+	httpassert.JSONBody(t, "resp", resp, `{"id": 1}`)
+
+The body of a response is read once and restored, so that it can be checked by more than one
+assertion. Failures of the status and headers show the beginning of the body as context.
+
+See also testingp.RecordingTransport for checking the requests sent by clients.
+*/
+package httpassert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+// The maximum number of bytes of a body shown as the context of failures.
+var MaxBodyContext = 256
+
+// Assertions provides the assertions of HTTP responses on a testing.TB.
+type Assertions struct {
+	t testing.TB
+	a *assert.Assertions
+}
+
+// New returns an Assertions on t, whose failures are reported as those of assert.New(t, opts...).
+func New(t testing.TB, opts ...assert.Option) *Assertions {
+	return &Assertions{t: t, a: assert.New(t, opts...)}
+}
+
+// readBody reads the body of resp and replaces it with a reader of the content read, so that it
+// can be read again.
+func readBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil {
+		return nil, nil
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	return data, err
+}
+
+// bodyContext returns the beginning of body as the context of failures.
+func bodyContext(body []byte) string {
+	if len(body) > MaxBodyContext {
+		return fmt.Sprintf("%q... (%d bytes)", body[:MaxBodyContext], len(body))
+	}
+	return fmt.Sprintf("%q", body)
+}
+
+// withBody returns the assert.Assertions of a with the body of resp as context.
+func (a *Assertions) withBody(resp *http.Response) *assert.Assertions {
+	body, err := readBody(resp)
+	if err != nil {
+		return a.a.Withf("body: reading failed: %v", err)
+	}
+	return a.a.Withf("body: %s", bodyContext(body))
+}
+
+// checkResponse reports a failure if resp is nil.
+func (a *Assertions) checkResponse(name string, resp *http.Response) bool {
+	a.t.Helper()
+	if resp != nil {
+		return true
+	}
+	return a.a.NotNil(name, nil)
+}
+
+// Status checks whether the status code of resp is exp, e.g. http.StatusOK.
+func Status(t testing.TB, name string, resp *http.Response, exp int) bool {
+	t.Helper()
+	return New(t).Status(name, resp, exp)
+}
+
+// Status is the same as the package-level Status.
+func (a *Assertions) Status(name string, resp *http.Response, exp int) bool {
+	a.t.Helper()
+	if !a.checkResponse(name, resp) {
+		return false
+	}
+	if resp.StatusCode == exp {
+		return true
+	}
+	return a.withBody(resp).StringEqual(name+" status", statusText(resp.StatusCode), statusText(exp))
+}
+
+func statusText(code int) string {
+	return fmt.Sprintf("%d %s", code, http.StatusText(code))
+}
+
+// Header checks whether the header key of resp is exp. Multiple values of the key are joined by
+// ", ". A missing header is the same as an empty one.
+func Header(t testing.TB, name string, resp *http.Response, key, exp string) bool {
+	t.Helper()
+	return New(t).Header(name, resp, key, exp)
+}
+
+// Header is the same as the package-level Header.
+func (a *Assertions) Header(name string, resp *http.Response, key, exp string) bool {
+	a.t.Helper()
+	if !a.checkResponse(name, resp) {
+		return false
+	}
+	act := strings.Join(resp.Header.Values(key), ", ")
+	if act == exp {
+		return true
+	}
+	return a.withBody(resp).StringEqual(fmt.Sprintf("%s header %s", name, http.CanonicalHeaderKey(key)), act, exp)
+}
+
+// ContentType checks whether the media type of the Content-Type header of resp is exp, e.g.
+// "application/json", ignoring parameters like charset.
+func ContentType(t testing.TB, name string, resp *http.Response, exp string) bool {
+	t.Helper()
+	return New(t).ContentType(name, resp, exp)
+}
+
+// ContentType is the same as the package-level ContentType.
+func (a *Assertions) ContentType(name string, resp *http.Response, exp string) bool {
+	a.t.Helper()
+	if !a.checkResponse(name, resp) {
+		return false
+	}
+	act := resp.Header.Get("Content-Type")
+	if mt, _, err := mime.ParseMediaType(act); err == nil && mt == exp {
+		return true
+	}
+	return a.withBody(resp).StringEqual(name+" content type", act, exp)
+}
+
+// Body checks whether the body of resp is exp, compared as assert.StringEqual does, i.e.
+// multi-line bodies are diffed by lines.
+func Body(t testing.TB, name string, resp *http.Response, exp string) bool {
+	t.Helper()
+	return New(t).Body(name, resp, exp)
+}
+
+// Body is the same as the package-level Body.
+func (a *Assertions) Body(name string, resp *http.Response, exp string) bool {
+	a.t.Helper()
+	if !a.checkResponse(name, resp) {
+		return false
+	}
+	body, err := readBody(resp)
+	if !a.a.NoError(err) {
+		return false
+	}
+	return a.a.StringEqual(name+" body", string(body), exp)
+}
+
+// JSONBody checks whether the body of resp is a JSON document semantically equal to exp, compared
+// as assert.JSONEqual does. exp could be a string, a []byte or any value marshaled by
+// encoding/json.
+func JSONBody(t testing.TB, name string, resp *http.Response, exp interface{}, opts ...assert.CompareOption) bool {
+	t.Helper()
+	return New(t).JSONBody(name, resp, exp, opts...)
+}
+
+// JSONBody is the same as the package-level JSONBody.
+func (a *Assertions) JSONBody(name string, resp *http.Response, exp interface{}, opts ...assert.CompareOption) bool {
+	a.t.Helper()
+	if !a.checkResponse(name, resp) {
+		return false
+	}
+	body, err := readBody(resp)
+	if !a.a.NoError(err) {
+		return false
+	}
+	aa := a.a
+	if !json.Valid(body) {
+		aa = aa.Withf("body: %s", bodyContext(body))
+	}
+	return aa.JSONEqual(name+" body", body, exp, opts...)
+}
@@ -0,0 +1,96 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpassert
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+	"github.com/golangplus/testing/assert"
+)
+
+func itemHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/items/1" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Add("Cache-Control", "no-cache")
+	w.Header().Add("Cache-Control", "private")
+	fmt.Fprint(w, `{"id": 1, "tags": ["a", "b"]}`)
+}
+
+func get(t *testing.T, path string) *http.Response {
+	rec := httptest.NewRecorder()
+	itemHandler(rec, httptest.NewRequest("GET", path, nil))
+	return rec.Result()
+}
+
+func ExampleJSONBody() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, assert.FilePosition(false))
+
+	rec := httptest.NewRecorder()
+	itemHandler(rec, httptest.NewRequest("GET", "/items/1", nil))
+	a.JSONBody("resp", rec.Result(), `{"tags": ["a", "c"], "id": 1}`)
+
+	// OUTPUT:
+	// resp body is unexpected:
+	//   resp body/tags/1 is expected to be "c", but got "b"
+}
+
+func TestAssertions(t *testing.T) {
+	resp := get(t, "/items/1")
+	assert.True(t, "Status", Status(t, "resp", resp, http.StatusOK))
+	assert.True(t, "Header", Header(t, "resp", resp, "cache-control", "no-cache, private"))
+	assert.True(t, "Header", Header(t, "resp", resp, "X-None", ""))
+	assert.True(t, "ContentType", ContentType(t, "resp", resp, "application/json"))
+	assert.True(t, "JSONBody", JSONBody(t, "resp", resp, map[string]interface{}{"id": 1, "tags": []string{"a", "b"}}))
+	// The body could be checked again.
+	assert.True(t, "Body", Body(t, "resp", resp, `{"id": 1, "tags": ["a", "b"]}`))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, assert.FilePosition(false))
+	resp = get(t, "/none")
+	assert.False(t, "Status", a.Status("resp", resp, http.StatusOK))
+	assert.False(t, "Header", a.Header("resp", resp, "x-id", "1"))
+	assert.False(t, "ContentType", a.ContentType("resp", resp, "application/json"))
+	assert.False(t, "Body", a.Body("resp", resp, "404 page not found\n\n"))
+	assert.False(t, "JSONBody", a.JSONBody("resp", resp, `{}`))
+	assert.False(t, "Status", a.Status("resp", nil, http.StatusOK))
+
+	assert.StringEqual(t, "log", "\n"+string(b), `
+resp status is expected to be "200 OK", but got "404 Not Found"
+  body: "404 page not found\n"
+resp header X-Id is expected to be "1", but got ""
+  body: "404 page not found\n"
+resp content type is expected to be
+  "application/json"
+but got
+  "text/plain; charset=utf-8"
+  body: "404 page not found\n"
+Unexpected resp body: exp 3, act 2 lines
+  Difference(expected ---  actual +++)
+    ---   3: ""
+assert: resp body is not valid JSON: unexpected data after the top-level value
+  body: "404 page not found\n"
+resp is unexpectedly nil
+`)
+}
+
+func TestBodyContext(t *testing.T) {
+	defer func(n int) { MaxBodyContext = n }(MaxBodyContext)
+	MaxBodyContext = 4
+	assert.StringEqual(t, "short", bodyContext([]byte("abc")), `"abc"`)
+	assert.StringEqual(t, "long", bodyContext([]byte(strings.Repeat("x", 10))), `"xxxx"... (10 bytes)`)
+}
@@ -0,0 +1,243 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// CompareOption customizes the comparison of structured documents, e.g. in JSONEqual.
+type CompareOption func(*compareOptions)
+
+type compareOptions struct {
+	exactNumbers    bool
+	numberTolerance float64
+}
+
+// ExactNumbers makes two numbers equal only if their literals are the same, e.g. 1 and 1.0 are
+// different. By default, numbers are compared by their values.
+func ExactNumbers() CompareOption {
+	return func(o *compareOptions) {
+		o.exactNumbers = true
+	}
+}
+
+// NumberTolerance makes two numbers equal if the absolute difference of their values is not
+// greater than tolerance.
+func NumberTolerance(tolerance float64) CompareOption {
+	return func(o *compareOptions) {
+		o.numberTolerance = tolerance
+	}
+}
+
+func newCompareOptions(opts []CompareOption) *compareOptions {
+	o := &compareOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *compareOptions) numberEqual(act, exp json.Number) bool {
+	if act == exp {
+		return true
+	}
+	if o.exactNumbers {
+		return false
+	}
+	if o.numberTolerance > 0 {
+		a, errA := act.Float64()
+		e, errE := exp.Float64()
+		if errA == nil && errE == nil {
+			return math.Abs(a-e) <= o.numberTolerance
+		}
+	}
+	a, okA := new(big.Rat).SetString(string(act))
+	e, okE := new(big.Rat).SetString(string(exp))
+	return okA && okE && a.Cmp(e) == 0
+}
+
+func parseJSON(v interface{}) (interface{}, error) {
+	var data []byte
+	// Types based on a string or a []byte, e.g. json.RawMessage, are also documents.
+	switch rv := reflect.ValueOf(v); {
+	case rv.Kind() == reflect.String:
+		data = []byte(rv.String())
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		data = rv.Bytes()
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after the top-level value")
+	}
+	return doc, nil
+}
+
+// JSONEqual compares two JSON documents semantically, i.e. the order of object members and
+// whitespaces are ignored. act and exp could be a string, a []byte, a type based on them, e.g.
+// json.RawMessage, or any value that is marshaled with encoding/json first. Differences are
+// reported with JSON pointer paths, e.g. "resp/items/0/id".
+func JSONEqual(t testing.TB, name string, act, exp interface{}, opts ...CompareOption) bool {
+	t.Helper()
+	return New(t).JSONEqual(name, act, exp, opts...)
+}
+
+// JSONEqual is the same as the package-level JSONEqual.
+func (a *Assertions) JSONEqual(name string, act, exp interface{}, opts ...CompareOption) bool {
+	a.t.Helper()
+	actDoc, err := parseJSON(act)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s is not valid JSON: %v", name, err))
+		return false
+	}
+	expDoc, err := parseJSON(exp)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: expected %s is not valid JSON: %v", name, err))
+		return false
+	}
+	if m, eq := documentDiff(name, actDoc, expDoc, a.compareOptions(opts)); !eq {
+		a.fail(m)
+		return false
+	}
+	return true
+}
+
+// documentDiff compares two decoded documents consisting of map[string]interface{},
+// []interface{}, json.Number, string, bool and nil.
+func documentDiff(name string, act, exp interface{}, o *compareOptions) (message string, equal bool) {
+	return joinDiffs(name, treeDiff(name, act, exp, o))
+}
+
+// joinDiffs combines the difference lines found under the root name into a message. A single
+// difference of the root itself is returned as it is.
+func joinDiffs(name string, m []string) (message string, equal bool) {
+	if len(m) == 0 {
+		return "", true
+	}
+	if len(m) == 1 && strings.HasPrefix(m[0], name+" ") {
+		return m[0], false
+	}
+	return fmt.Sprintf("%s is unexpected:\n  %s", name, strings.Join(m, "\n  ")), false
+}
+
+func escapePointerToken(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+func documentValueMessage(v interface{}) string {
+	switch v := v.(type) {
+	case json.Number:
+		return v.String()
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+func documentKind(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func documentDiffMessage(path string, act, exp interface{}) string {
+	actMsg, expMsg := documentValueMessage(act), documentValueMessage(exp)
+	if actK, expK := documentKind(act), documentKind(exp); actK != expK {
+		actMsg = fmt.Sprintf("%s(%s)", actMsg, actK)
+		expMsg = fmt.Sprintf("%s(%s)", expMsg, expK)
+	}
+	return fmt.Sprintf("%s is expected to be %s, but got %s", path, expMsg, actMsg)
+}
+
+func treeDiff(path string, act, exp interface{}, o *compareOptions) []string {
+	if documentKind(act) != documentKind(exp) {
+		return []string{documentDiffMessage(path, act, exp)}
+	}
+	switch exp := exp.(type) {
+	case map[string]interface{}:
+		act := act.(map[string]interface{})
+		keys := make([]string, 0, len(act)+len(exp))
+		for k := range act {
+			keys = append(keys, k)
+		}
+		for k := range exp {
+			if _, ok := act[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		var m []string
+		for _, k := range keys {
+			p := path + "/" + escapePointerToken(k)
+			actV, actOK := act[k]
+			expV, expOK := exp[k]
+			switch {
+			case !expOK:
+				m = append(m, fmt.Sprintf("extra %s -> %s", p, documentValueMessage(actV)))
+			case !actOK:
+				m = append(m, fmt.Sprintf("missing %s -> %s", p, documentValueMessage(expV)))
+			default:
+				m = append(m, treeDiff(p, actV, expV, o)...)
+			}
+		}
+		return m
+	case []interface{}:
+		act := act.([]interface{})
+		var m []string
+		for i := 0; i < len(act) || i < len(exp); i++ {
+			p := fmt.Sprintf("%s/%d", path, i)
+			switch {
+			case i >= len(exp):
+				m = append(m, fmt.Sprintf("extra %s -> %s", p, documentValueMessage(act[i])))
+			case i >= len(act):
+				m = append(m, fmt.Sprintf("missing %s -> %s", p, documentValueMessage(exp[i])))
+			default:
+				m = append(m, treeDiff(p, act[i], exp[i], o)...)
+			}
+		}
+		return m
+	case json.Number:
+		if o.numberEqual(act.(json.Number), exp) {
+			return nil
+		}
+	default:
+		if act == exp {
+			return nil
+		}
+	}
+	return []string{documentDiffMessage(path, act, exp)}
+}
@@ -0,0 +1,55 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func ExampleJSONEqual() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
+
+	a.JSONEqual("resp", `{"id": 1, "tags": ["a", "b"], "extra": null}`, `{"tags": ["a", "c", "d"], "id": 1.0, "name": "x"}`)
+
+	// OUTPUT:
+	// resp is unexpected:
+	//   extra resp/extra -> null
+	//   missing resp/name -> "x"
+	//   resp/tags/1 is expected to be "c", but got "b"
+	//   missing resp/tags/2 -> "d"
+}
+
+func TestJSONEqual(t *testing.T) {
+	True(t, "return value", JSONEqual(t, "v", `{"a": [1, 2e0], "b": {"c/d": true}}`, []byte(`{"b":{"c/d":true},"a":[1.0,2]}`)))
+	True(t, "return value", JSONEqual(t, "v", map[string]int{"a": 1}, `{"a": 1}`))
+	True(t, "return value", JSONEqual(t, "v", `0.1`, `0.10001`, NumberTolerance(0.001)))
+	type Raw string
+	type RawBytes []byte
+	True(t, "return value", JSONEqual(t, "v", Raw(`{"a": 1}`), RawBytes(`{"a":1}`)))
+	True(t, "return value", JSONEqual(t, "v", json.RawMessage(`[1]`), `[1]`))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+
+	False(t, "return value", a.JSONEqual("v", `1`, `1.0`, ExactNumbers()))
+	False(t, "return value", a.JSONEqual("v", `{"a/b": {"~": 1}}`, `{"a/b": {"~": "1"}}`))
+	False(t, "return value", a.JSONEqual("v", `{`, `{}`))
+	False(t, "return value", a.JSONEqual("v", `{}`, `{} {}`))
+	StringEqual(t, "output", "\n"+string(b), `
+v is expected to be 1.0, but got 1
+v is unexpected:
+  v/a~1b/~0 is expected to be "1"(string), but got 1(number)
+assert: v is not valid JSON: unexpected EOF
+assert: expected v is not valid JSON: unexpected data after the top-level value
+`)
+}
@@ -0,0 +1,279 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// matchText returns the text of act to match against patterns: a string or a []byte as it is, or
+// fmt.Sprint of other values.
+func matchText(act interface{}) string {
+	switch v := act.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return fmt.Sprint(act)
+}
+
+// globToRegexp converts a glob pattern into a regular expression matching whole strings. In glob
+// patterns, '*' matches any sequence of characters, including '/' and newlines, '?' matches any
+// single character, '[...]' matches a character class as in regexp, and '\\' escapes the next
+// character.
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString(`(?s)^`)
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			b.WriteString(`.*`)
+		case '?':
+			b.WriteString(`.`)
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("missing closing ] in %q", glob)
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += 1 + end
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+			}
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			// A byte of a multi-byte UTF-8 character is written as it is, not as a rune.
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString(`$`)
+	return regexp.Compile(b.String())
+}
+
+// matchMessage returns the message of a failure of matching act, e.g.
+// "v is expected to match /a+/, but got \"b\"".
+func matchMessage(name, expectation, pattern, act string) string {
+	msg := fmt.Sprintf("%s is %s %s, but got %q", name, expectation, pattern, act)
+	if len(msg) >= 80 {
+		msg = fmt.Sprintf("%s is %s\n  %s\nbut got\n  %q", name, expectation, pattern, act)
+	}
+	return msg
+}
+
+func (a *Assertions) matches(name string, act interface{}, re *regexp.Regexp, err error, pattern string, want bool) bool {
+	a.t.Helper()
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: invalid pattern: %v", err))
+		return false
+	}
+	s := matchText(act)
+	if re.MatchString(s) == want {
+		return true
+	}
+	expectation := "expected to match"
+	if !want {
+		expectation = "not expected to match"
+	}
+	a.fail(matchMessage(name, expectation, pattern, s))
+	return false
+}
+
+// Matches checks whether act matches the regular expression pattern. act is a string, a []byte, or
+// a value formatted by fmt.Sprint.
+func Matches(t testing.TB, name string, act interface{}, pattern string) bool {
+	t.Helper()
+	return New(t).Matches(name, act, pattern)
+}
+
+// Matches is the same as the package-level Matches.
+func (a *Assertions) Matches(name string, act interface{}, pattern string) bool {
+	a.t.Helper()
+	re, err := regexp.Compile(pattern)
+	return a.matches(name, act, re, err, "/"+pattern+"/", true)
+}
+
+// NotMatches checks whether act does not match the regular expression pattern. See Matches.
+func NotMatches(t testing.TB, name string, act interface{}, pattern string) bool {
+	t.Helper()
+	return New(t).NotMatches(name, act, pattern)
+}
+
+// NotMatches is the same as the package-level NotMatches.
+func (a *Assertions) NotMatches(name string, act interface{}, pattern string) bool {
+	a.t.Helper()
+	re, err := regexp.Compile(pattern)
+	return a.matches(name, act, re, err, "/"+pattern+"/", false)
+}
+
+// MatchesGlob checks whether the whole act matches the glob pattern, where '*' matches any
+// characters, including '/' and newlines, '?' matches a single character and '[...]' or '[!...]'
+// matches a character class. act is a string, a []byte, or a value formatted by fmt.Sprint.
+func MatchesGlob(t testing.TB, name string, act interface{}, glob string) bool {
+	t.Helper()
+	return New(t).MatchesGlob(name, act, glob)
+}
+
+// MatchesGlob is the same as the package-level MatchesGlob.
+func (a *Assertions) MatchesGlob(name string, act interface{}, glob string) bool {
+	a.t.Helper()
+	re, err := globToRegexp(glob)
+	return a.matches(name, act, re, err, fmt.Sprintf("glob %q", glob), true)
+}
+
+// NotMatchesGlob checks whether act does not match the glob pattern. See MatchesGlob.
+func NotMatchesGlob(t testing.TB, name string, act interface{}, glob string) bool {
+	t.Helper()
+	return New(t).NotMatchesGlob(name, act, glob)
+}
+
+// NotMatchesGlob is the same as the package-level NotMatchesGlob.
+func (a *Assertions) NotMatchesGlob(name string, act interface{}, glob string) bool {
+	a.t.Helper()
+	re, err := globToRegexp(glob)
+	return a.matches(name, act, re, err, fmt.Sprintf("glob %q", glob), false)
+}
+
+// lineCheck is a line of the checks of MatchesLines.
+type lineCheck struct {
+	// The line of the check, e.g. "CHECK-NEXT: ^ok$".
+	text string
+	kind string
+	re   *regexp.Regexp
+}
+
+const (
+	checkAny  = "CHECK"
+	checkNext = "CHECK-NEXT"
+	checkNot  = "CHECK-NOT"
+)
+
+func parseLineChecks(checks string) ([]lineCheck, error) {
+	var res []lineCheck
+	for i, line := range strings.Split(checks, "\n") {
+		text := strings.TrimSpace(line)
+		if text == "" {
+			continue
+		}
+		p := strings.Index(text, ":")
+		if p < 0 {
+			return nil, fmt.Errorf("line %d: missing CHECK prefix in %q", i+1, text)
+		}
+		c := lineCheck{text: text, kind: text[:p]}
+		switch c.kind {
+		case checkAny, checkNext, checkNot:
+		default:
+			return nil, fmt.Errorf("line %d: unknown check %q", i+1, c.kind)
+		}
+		var err error
+		if c.re, err = regexp.Compile(strings.TrimSpace(text[p+1:])); err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+// checkLines checks the lines against the checks and returns the message of the first failure, or
+// "" if all checks pass.
+func checkLines(name string, lines []string, checks []lineCheck) string {
+	// The index of the next line to check, and the CHECK-NOT checks since the last matched line.
+	pos, nots := 0, []lineCheck(nil)
+	// verifyNots checks lines[pos:end] against nots.
+	verifyNots := func(end int) string {
+		for i := pos; i < end; i++ {
+			for _, c := range nots {
+				if c.re.MatchString(lines[i]) {
+					return fmt.Sprintf("%s line %d %q matches %q", name, i+1, lines[i], c.text)
+				}
+			}
+		}
+		return ""
+	}
+	for ci, c := range checks {
+		found := -1
+		switch c.kind {
+		case checkNot:
+			nots = append(nots, c)
+			continue
+		case checkNext:
+			if pos < len(lines) && c.re.MatchString(lines[pos]) {
+				found = pos
+			}
+		default:
+			for i := pos; i < len(lines); i++ {
+				if c.re.MatchString(lines[i]) {
+					found = i
+					break
+				}
+			}
+		}
+		if found < 0 {
+			return checkFailure(name, lines, pos, checks[ci:])
+		}
+		if m := verifyNots(found); m != "" {
+			return m
+		}
+		pos, nots = found+1, nil
+	}
+	return verifyNots(len(lines))
+}
+
+// checkFailure returns the message of a check failing with lines from pos, which shows the diff of
+// the remaining checks and lines, matching a check and a line if the line matches the pattern.
+func checkFailure(name string, lines []string, pos int, checks []lineCheck) string {
+	var texts []string
+	var patterns []*regexp.Regexp
+	for _, c := range checks {
+		if c.kind != checkNot {
+			texts, patterns = append(texts, c.text), append(patterns, c.re)
+		}
+	}
+	m := []string{
+		fmt.Sprintf("%s does not match %q from line %d", name, texts[0], pos+1),
+		"  Difference(expected ---  actual +++)",
+	}
+	m = append(m, diffLines(lines[pos:], texts, pos+1, 1, func(expI, actI int) bool {
+		return patterns[expI].MatchString(lines[pos+actI])
+	})...)
+	return strings.Join(m, "\n")
+}
+
+// MatchesLines checks the lines of act against checks, an ordered list of line patterns, one per
+// line, similar to those of LLVM FileCheck:
+//
+//	CHECK: <regexp>       matches a line after the line matched by the previous check.
+//	CHECK-NEXT: <regexp>  matches the line right after the line matched by the previous check.
+//	CHECK-NOT: <regexp>   matches no lines between those matched by the surrounding checks.
+//
+// Blank lines of checks are ignored. act is a string, a []byte, or a value formatted by
+// fmt.Sprint. On failure, the remaining checks are diffed with the remaining lines.
+func MatchesLines(t testing.TB, name string, act interface{}, checks string) bool {
+	t.Helper()
+	return New(t).MatchesLines(name, act, checks)
+}
+
+// MatchesLines is the same as the package-level MatchesLines.
+func (a *Assertions) MatchesLines(name string, act interface{}, checks string) bool {
+	a.t.Helper()
+	cs, err := parseLineChecks(checks)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: invalid checks: %v", err))
+		return false
+	}
+	if m := checkLines(name, strings.Split(matchText(act), "\n"), cs); m != "" {
+		a.fail(m)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func ExampleMatchesLines() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
+
+	a.MatchesLines("log", "starting\nlistening on :8080\nready\nshutting down", `
+CHECK: ^listening on :\d+$
+CHECK-NEXT: ^serving$
+CHECK: ^shutting down$
+`)
+
+	// OUTPUT:
+	// log does not match "CHECK-NEXT: ^serving$" from line 3
+	//   Difference(expected ---  actual +++)
+	//     ---   1: "CHECK-NEXT: ^serving$"
+	//     +++   3: "ready"
+}
+
+func TestMatches(t *testing.T) {
+	True(t, "Matches", Matches(t, "v", "abc", `b+`))
+	True(t, "Matches", Matches(t, "v", []byte("abc"), `^abc$`))
+	True(t, "Matches", Matches(t, "v", errors.New("code 404"), `^code \d+$`))
+	True(t, "NotMatches", NotMatches(t, "v", "abc", `d`))
+	True(t, "MatchesGlob", MatchesGlob(t, "v", "a/b.go", `*.go`))
+	True(t, "MatchesGlob", MatchesGlob(t, "v", "a1\n.[x]", `a[0-9]?.\[x]`))
+	True(t, "MatchesGlob", MatchesGlob(t, "v", "ab", `a[!c]`))
+	True(t, "MatchesGlob", MatchesGlob(t, "v", "café", "café"))
+	True(t, "MatchesGlob", MatchesGlob(t, "v", "日本.txt", `日?.*`))
+	True(t, "NotMatchesGlob", NotMatchesGlob(t, "v", "a.go", `*.txt`))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "Matches", a.Matches("v", "abc", `^b`))
+	False(t, "NotMatches", a.NotMatches("v", 123, `2`))
+	False(t, "MatchesGlob", a.MatchesGlob("v", "a.go", `*.txt`))
+	False(t, "MatchesGlob", a.MatchesGlob("v", "a.go", `[a`))
+	False(t, "NotMatchesGlob", a.NotMatchesGlob("v", "a.go", `a.*`))
+	False(t, "Matches", a.Matches("v", "abc", `(`))
+	False(t, "long", a.Matches("v", "01234567890123456789012345678901234567890123456789", `^a`))
+
+	StringEqual(t, "log", "\n"+string(b), `
+v is expected to match /^b/, but got "abc"
+v is not expected to match /2/, but got "123"
+v is expected to match glob "*.txt", but got "a.go"
+assert: invalid pattern: missing closing ] in "[a"
+v is not expected to match glob "a.*", but got "a.go"
+assert: invalid pattern: error parsing regexp: missing closing ): `+"`(`"+`
+v is expected to match
+  /^a/
+but got
+  "01234567890123456789012345678901234567890123456789"
+`)
+}
+
+func TestMatchesLines(t *testing.T) {
+	out := "a\nb\nc\nd"
+	True(t, "CHECK", MatchesLines(t, "v", out, "CHECK: b\nCHECK: d"))
+	True(t, "CHECK-NEXT", MatchesLines(t, "v", out, "CHECK-NEXT: a\n  CHECK-NEXT: b\n\nCHECK: d"))
+	True(t, "CHECK-NOT", MatchesLines(t, "v", out, "CHECK: a\nCHECK-NOT: x\nCHECK: c\nCHECK-NOT: a"))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "CHECK", a.MatchesLines("v", out, "CHECK: c\nCHECK: b\nCHECK: d"))
+	False(t, "CHECK-NOT", a.MatchesLines("v", out, "CHECK: a\nCHECK-NOT: b\nCHECK: c"))
+	False(t, "CHECK-NOT at end", a.MatchesLines("v", out, "CHECK: b\nCHECK-NOT: d"))
+	False(t, "invalid", a.MatchesLines("v", out, "CHECK: a\nb"))
+	False(t, "invalid", a.MatchesLines("v", out, "CHECK-ALL: a"))
+	False(t, "invalid", a.MatchesLines("v", out, "CHECK: ("))
+
+	StringEqual(t, "log", "\n"+string(b), `
+v does not match "CHECK: b" from line 4
+  Difference(expected ---  actual +++)
+    ---   1: "CHECK: b"
+v line 2 "b" matches "CHECK-NOT: b"
+v line 4 "d" matches "CHECK-NOT: d"
+assert: invalid checks: line 2: missing CHECK prefix in "b"
+assert: invalid checks: line 1: unknown check "CHECK-ALL"
+assert: invalid checks: line 1: error parsing regexp: missing closing ): `+"`(`"+`
+`)
+}
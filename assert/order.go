@@ -0,0 +1,262 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// compareOrdered returns -1, 0 or 1 if x is less than, equal to or greater than y. x and y must
+// be of the same type, whose kind is an integer, a float or a string, or time.Time.
+func compareOrdered(x, y reflect.Value) (int, error) {
+	if !x.IsValid() || !y.IsValid() || x.Type() != y.Type() {
+		return 0, fmt.Errorf("expecting values of the same type, but got %s and %s", typeName(x), typeName(y))
+	}
+	sign := func(less, greater bool) int {
+		switch {
+		case less:
+			return -1
+		case greater:
+			return 1
+		}
+		return 0
+	}
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return sign(x.Int() < y.Int(), x.Int() > y.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return sign(x.Uint() < y.Uint(), x.Uint() > y.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(x.Float()) || math.IsNaN(y.Float()) {
+			return 0, fmt.Errorf("NaN is not ordered")
+		}
+		return sign(x.Float() < y.Float(), x.Float() > y.Float()), nil
+	case reflect.String:
+		return strings.Compare(x.String(), y.String()), nil
+	}
+	if x.Type() == timeType {
+		tx, ty := x.Interface().(time.Time), y.Interface().(time.Time)
+		return sign(tx.Before(ty), tx.After(ty)), nil
+	}
+	return 0, fmt.Errorf("expecting ordered values, but got %v", x.Type())
+}
+
+// orderedMessage returns the message of an ordered value, by its String method if it has one,
+// e.g. for a time.Duration.
+func orderedMessage(v reflect.Value) string {
+	if s, ok := v.Interface().(fmt.Stringer); ok && isBasicKind(v.Kind()) {
+		return fmt.Sprintf("%s(%s)", v.Type(), s)
+	}
+	return prettyValue(v, false)
+}
+
+// order checks the order of act and bound, where want returns whether the result of
+// compareOrdered(act, bound) is expected.
+func (a *Assertions) order(name string, act, bound interface{}, relation string, want func(c int) bool) bool {
+	a.t.Helper()
+	c, err := compareOrdered(reflect.ValueOf(act), reflect.ValueOf(bound))
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return false
+	}
+	if want(c) {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is expected to be %s %s, but got %s", name, relation,
+		orderedMessage(reflect.ValueOf(bound)), orderedMessage(reflect.ValueOf(act))))
+	return false
+}
+
+// Less checks whether act < than. act and than must be of the same type, whose kind is an integer,
+// a float or a string, or time.Time.
+func Less(t testing.TB, name string, act, than interface{}) bool {
+	t.Helper()
+	return New(t).Less(name, act, than)
+}
+
+// Less is the same as the package-level Less.
+func (a *Assertions) Less(name string, act, than interface{}) bool {
+	a.t.Helper()
+	return a.order(name, act, than, "less than", func(c int) bool { return c < 0 })
+}
+
+// LessOrEqual checks whether act <= than. See Less.
+func LessOrEqual(t testing.TB, name string, act, than interface{}) bool {
+	t.Helper()
+	return New(t).LessOrEqual(name, act, than)
+}
+
+// LessOrEqual is the same as the package-level LessOrEqual.
+func (a *Assertions) LessOrEqual(name string, act, than interface{}) bool {
+	a.t.Helper()
+	return a.order(name, act, than, "less than or equal to", func(c int) bool { return c <= 0 })
+}
+
+// Greater checks whether act > than. See Less.
+func Greater(t testing.TB, name string, act, than interface{}) bool {
+	t.Helper()
+	return New(t).Greater(name, act, than)
+}
+
+// Greater is the same as the package-level Greater.
+func (a *Assertions) Greater(name string, act, than interface{}) bool {
+	a.t.Helper()
+	return a.order(name, act, than, "greater than", func(c int) bool { return c > 0 })
+}
+
+// GreaterOrEqual checks whether act >= than. See Less.
+func GreaterOrEqual(t testing.TB, name string, act, than interface{}) bool {
+	t.Helper()
+	return New(t).GreaterOrEqual(name, act, than)
+}
+
+// GreaterOrEqual is the same as the package-level GreaterOrEqual.
+func (a *Assertions) GreaterOrEqual(name string, act, than interface{}) bool {
+	a.t.Helper()
+	return a.order(name, act, than, "greater than or equal to", func(c int) bool { return c >= 0 })
+}
+
+// Between checks whether lo <= act <= hi. See Less.
+func Between(t testing.TB, name string, act, lo, hi interface{}) bool {
+	t.Helper()
+	return New(t).Between(name, act, lo, hi)
+}
+
+// Between is the same as the package-level Between.
+func (a *Assertions) Between(name string, act, lo, hi interface{}) bool {
+	a.t.Helper()
+	actV := reflect.ValueOf(act)
+	cLo, err := compareOrdered(actV, reflect.ValueOf(lo))
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return false
+	}
+	cHi, err := compareOrdered(actV, reflect.ValueOf(hi))
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return false
+	}
+	if cLo >= 0 && cHi <= 0 {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is expected to be within [%s, %s], but got %s", name,
+		orderedMessage(reflect.ValueOf(lo)), orderedMessage(reflect.ValueOf(hi)), orderedMessage(actV)))
+	return false
+}
+
+// sortedness checks whether the slice or array s is sorted by less, which returns whether s[i] is
+// less than s[j]. Returns the message of the first element out of order, or "" if sorted.
+func sortedness(name string, s reflect.Value, less func(i, j int) (bool, error)) (string, error) {
+	for i := 1; i < s.Len(); i++ {
+		l, err := less(i, i-1)
+		if err != nil {
+			return "", err
+		}
+		if !l {
+			continue
+		}
+		lo, hi := i-2, i+2
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > s.Len() {
+			hi = s.Len()
+		}
+		return fmt.Sprintf("%s is not sorted at index %d: %s[%d] = %s, %s[%d] = %s, %s[%d:%d] = %s", name, i,
+			name, i-1, orderedMessage(s.Index(i-1)), name, i, orderedMessage(s.Index(i)),
+			name, lo, hi, indentValue(prettyValue(s.Slice(lo, hi), false))), nil
+	}
+	return "", nil
+}
+
+// sliceable returns v as a sliceable value if it is a slice or an array.
+func sliceable(v reflect.Value) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Slice:
+		return v, nil
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		return c, nil
+	}
+	return v, fmt.Errorf("expecting a slice or an array, but got %s", typeName(v))
+}
+
+// IsSorted checks whether the elements of s, a slice or an array of ordered values, are in
+// non-decreasing order. See Less for ordered values. On failure, the first element out of order
+// and its neighbors are reported.
+func IsSorted(t testing.TB, name string, s interface{}) bool {
+	t.Helper()
+	return New(t).IsSorted(name, s)
+}
+
+// IsSorted is the same as the package-level IsSorted.
+func (a *Assertions) IsSorted(name string, s interface{}) bool {
+	a.t.Helper()
+	v, err := sliceable(reflect.ValueOf(s))
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return false
+	}
+	m, err := sortedness(name, v, func(i, j int) (bool, error) {
+		c, err := compareOrdered(unwrapInterface(v.Index(i)), unwrapInterface(v.Index(j)))
+		return c < 0, err
+	})
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return false
+	}
+	if m != "" {
+		a.fail(m)
+		return false
+	}
+	return true
+}
+
+// isLessFunc returns whether t is a func(x, y T) bool, where elem is assignable to T.
+func isLessFunc(t, elem reflect.Type) bool {
+	return t.Kind() == reflect.Func && t.NumIn() == 2 && t.NumOut() == 1 && t.Out(0) == boolType &&
+		elem.AssignableTo(t.In(0)) && elem.AssignableTo(t.In(1))
+}
+
+// IsSortedFunc checks whether the elements of s, a slice or an array, are sorted by less, a
+// func(x, y T) bool returning whether x is less than y, where T is the element type of s. On
+// failure, the first element out of order and its neighbors are reported.
+func IsSortedFunc(t testing.TB, name string, s interface{}, less interface{}) bool {
+	t.Helper()
+	return New(t).IsSortedFunc(name, s, less)
+}
+
+// IsSortedFunc is the same as the package-level IsSortedFunc.
+func (a *Assertions) IsSortedFunc(name string, s interface{}, less interface{}) bool {
+	a.t.Helper()
+	v, err := sliceable(reflect.ValueOf(s))
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s: %v", name, err))
+		return false
+	}
+	f := reflect.ValueOf(less)
+	if !f.IsValid() || !isLessFunc(f.Type(), v.Type().Elem()) {
+		a.fail(fmt.Sprintf("assert: %s: less must be a func(x, y %v) bool, but got %s", name, v.Type().Elem(), typeName(f)))
+		return false
+	}
+	if f.IsNil() {
+		a.fail(fmt.Sprintf("assert: %s: less must not be a nil %s", name, typeName(f)))
+		return false
+	}
+	m, _ := sortedness(name, v, func(i, j int) (bool, error) {
+		return f.Call([]reflect.Value{v.Index(i), v.Index(j)})[0].Bool(), nil
+	})
+	if m != "" {
+		a.fail(m)
+		return false
+	}
+	return true
+}
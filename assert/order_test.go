@@ -0,0 +1,92 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func ExampleIsSorted() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
+
+	a.IsSorted("ids", []int{1, 3, 5, 4, 7, 9})
+
+	// OUTPUT:
+	// ids is not sorted at index 3: ids[2] = 5, ids[3] = 4, ids[1:5] = []int{3, 5, 4, 7}
+}
+
+func TestLess(t *testing.T) {
+	True(t, "Less", Less(t, "v", 1, 2))
+	True(t, "Less", Less(t, "v", "a", "b"))
+	True(t, "Less", Less(t, "v", time.Second, time.Minute))
+	True(t, "Less", Less(t, "v", time.Unix(1, 0), time.Unix(2, 0)))
+	True(t, "LessOrEqual", LessOrEqual(t, "v", uint8(2), uint8(2)))
+	True(t, "Greater", Greater(t, "v", 1.5, 1.0))
+	True(t, "GreaterOrEqual", GreaterOrEqual(t, "v", -1, -1))
+	True(t, "Between", Between(t, "v", 5, 1, 5))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "Less", a.Less("v", 2, 2))
+	False(t, "LessOrEqual", a.LessOrEqual("v", "b", "a"))
+	False(t, "Greater", a.Greater("v", 1.0, 1.5))
+	False(t, "GreaterOrEqual", a.GreaterOrEqual("v", time.Second, time.Minute))
+	False(t, "Between", a.Between("v", 0, 1, 5))
+	False(t, "type", a.Less("v", 1, int64(2)))
+	False(t, "NaN", a.Less("v", math.NaN(), 1.0))
+	False(t, "unordered", a.Less("v", true, false))
+	False(t, "Between", a.Between("v", 0, "a", 5))
+
+	StringEqual(t, "log", "\n"+string(b), `
+v is expected to be less than 2, but got 2
+v is expected to be less than or equal to "a", but got "b"
+v is expected to be greater than 1.5, but got 1
+v is expected to be greater than or equal to time.Duration(1m0s), but got time.Duration(1s)
+v is expected to be within [1, 5], but got 0
+assert: v: expecting values of the same type, but got int and int64
+assert: v: NaN is not ordered
+assert: v: expecting ordered values, but got bool
+assert: v: expecting values of the same type, but got int and string
+`)
+}
+
+func TestIsSorted(t *testing.T) {
+	True(t, "IsSorted", IsSorted(t, "v", []int{1, 1, 2}))
+	True(t, "IsSorted", IsSorted(t, "v", [3]string{"a", "b", "c"}))
+	True(t, "IsSorted", IsSorted(t, "v", []interface{}{1, 2}))
+	True(t, "IsSorted", IsSorted(t, "v", []float64(nil)))
+	True(t, "IsSortedFunc", IsSortedFunc(t, "v", []int{3, 2, 2}, func(x, y int) bool { return x > y }))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "IsSorted", a.IsSorted("v", [2]int{2, 1}))
+	False(t, "IsSortedFunc", a.IsSortedFunc("v", []string{"a", "bb", "c"}, func(x, y string) bool { return len(x) < len(y) }))
+	False(t, "not slice", a.IsSorted("v", 1))
+	False(t, "unordered", a.IsSorted("v", []bool{true, false}))
+	False(t, "less", a.IsSortedFunc("v", []int{1}, func(x, y string) bool { return x < y }))
+	False(t, "less", a.IsSortedFunc("v", []int{1}, nil))
+	var nilLess func(x, y int) bool
+	False(t, "less", a.IsSortedFunc("v", []int{1, 2}, nilLess))
+
+	StringEqual(t, "log", "\n"+string(b), `
+v is not sorted at index 1: v[0] = 2, v[1] = 1, v[0:2] = []int{2, 1}
+v is not sorted at index 2: v[1] = "bb", v[2] = "c", v[0:3] = []string{"a", "bb", "c"}
+assert: v: expecting a slice or an array, but got int
+assert: v: expecting ordered values, but got bool
+assert: v: less must be a func(x, y int) bool, but got func(string, string) bool
+assert: v: less must be a func(x, y int) bool, but got nil
+assert: v: less must not be a nil func(int, int) bool
+`)
+}
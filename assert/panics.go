@@ -0,0 +1,144 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+// capturePanic calls f and returns whether it panicked, the recovered value and the stack of the
+// goroutine where the panic happened.
+func capturePanic(f func()) (panicked bool, value interface{}, stack []byte) {
+	defer func() {
+		if panicked {
+			value, stack = recover(), debug.Stack()
+		}
+	}()
+	panicked = true
+	f()
+	panicked = false
+	return
+}
+
+// panicMessage returns the string a panic value shows, i.e. the message of an error or a
+// fmt.Stringer, or its default format.
+func panicMessage(v interface{}) string {
+	switch v := v.(type) {
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// typedValueMessage returns the message of v with its type, even if it is a basic value.
+func typedValueMessage(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	m := prettyValue(rv, false)
+	if !showsType(rv) {
+		m = fmt.Sprintf("%s(type %T)", m, v)
+	}
+	return indentValue(m)
+}
+
+// PanicsWithValue checks whether f panics with the value, compared as Equal does.
+func PanicsWithValue(t testing.TB, name string, f func(), value interface{}) bool {
+	t.Helper()
+	return New(t).PanicsWithValue(name, f, value)
+}
+
+// PanicsWithValue is the same as the package-level PanicsWithValue.
+func (a *Assertions) PanicsWithValue(name string, f func(), value interface{}) bool {
+	a.t.Helper()
+	panicked, v, _ := capturePanic(f)
+	if !panicked {
+		a.fail(fmt.Sprintf("%s does not panic as expected.", name))
+		return false
+	}
+	m, eq := a.compareOptions(nil).deepValueDiff("panic value of "+name, reflect.ValueOf(v), reflect.ValueOf(value))
+	if !eq {
+		a.fail(m)
+	}
+	return eq
+}
+
+// PanicsWithError checks whether f panics with an error matching target, like errors.Is. Errors
+// wrapped by the recovered one, including those in errors.Join trees, are also checked.
+func PanicsWithError(t testing.TB, name string, f func(), target error) bool {
+	t.Helper()
+	return New(t).PanicsWithError(name, f, target)
+}
+
+// PanicsWithError is the same as the package-level PanicsWithError.
+func (a *Assertions) PanicsWithError(name string, f func(), target error) bool {
+	a.t.Helper()
+	panicked, v, _ := capturePanic(f)
+	if !panicked {
+		a.fail(fmt.Sprintf("%s does not panic as expected.", name))
+		return false
+	}
+	err, ok := v.(error)
+	if !ok {
+		a.fail(fmt.Sprintf("%s is expected to panic with an error, but got %s", name, typedValueMessage(v)))
+		return false
+	}
+	if isError(err, target) {
+		return true
+	}
+	a.fail(errorMessage(fmt.Sprintf("%s is expected to panic with error matching %T(%q), but got", name, target, fmt.Sprint(target)), err))
+	return false
+}
+
+// PanicsMatching checks whether f panics with a value whose message matches the regular expression
+// pattern. The message of an error or a fmt.Stringer is its Error() or String(), and fmt.Sprint
+// of it for other values.
+func PanicsMatching(t testing.TB, name string, f func(), pattern string) bool {
+	t.Helper()
+	return New(t).PanicsMatching(name, f, pattern)
+}
+
+// PanicsMatching is the same as the package-level PanicsMatching.
+func (a *Assertions) PanicsMatching(name string, f func(), pattern string) bool {
+	a.t.Helper()
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: invalid pattern: %v", err))
+		return false
+	}
+	panicked, v, _ := capturePanic(f)
+	if !panicked {
+		a.fail(fmt.Sprintf("%s does not panic as expected.", name))
+		return false
+	}
+	if msg := panicMessage(v); !re.MatchString(msg) {
+		a.fail(fmt.Sprintf("%s is expected to panic with message matching /%s/, but got %q", name, pattern, msg))
+		return false
+	}
+	return true
+}
+
+// NotPanics checks whether f returns without panicking. On failure, the recovered value and the
+// stack of the goroutine where the panic happened are reported.
+func NotPanics(t testing.TB, name string, f func()) bool {
+	t.Helper()
+	return New(t).NotPanics(name, f)
+}
+
+// NotPanics is the same as the package-level NotPanics.
+func (a *Assertions) NotPanics(name string, f func()) bool {
+	a.t.Helper()
+	panicked, v, stack := capturePanic(f)
+	if !panicked {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s panics unexpectedly with %s\n  %s", name, typedValueMessage(v), indentValue(strings.TrimRight(string(stack), "\n"))))
+	return false
+}
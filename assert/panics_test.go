@@ -0,0 +1,71 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func ExamplePanicsWithValue() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
+
+	a.PanicsWithValue("f", func() { panic(404) }, 400)
+
+	// OUTPUT:
+	// panic value of f is expected to be 400, but got 404
+}
+
+func TestPanics(t *testing.T) {
+	True(t, "PanicsWithValue", PanicsWithValue(t, "f", func() { panic([]int{1}) }, []int{1}))
+	True(t, "PanicsWithError", PanicsWithError(t, "f", func() { panic(fmt.Errorf("read: %w", io.EOF)) }, io.EOF))
+	True(t, "PanicsMatching", PanicsMatching(t, "f", func() { panic(fmt.Errorf("code %d", 404)) }, `^code 4\d\d$`))
+	True(t, "PanicsMatching", PanicsMatching(t, "f", func() { panic(404) }, `^404$`))
+	True(t, "NotPanics", NotPanics(t, "f", func() {}))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "PanicsWithValue", a.PanicsWithValue("f", func() {}, 1))
+	False(t, "PanicsWithValue", a.PanicsWithValue("f", func() { panic(int64(1)) }, 1))
+	False(t, "PanicsWithError", a.PanicsWithError("f", func() { panic("EOF") }, io.EOF))
+	False(t, "PanicsWithError", a.PanicsWithError("f", func() { panic(io.ErrUnexpectedEOF) }, io.EOF))
+	False(t, "PanicsMatching", a.PanicsMatching("f", func() { panic("a") }, `b`))
+	False(t, "PanicsMatching", a.PanicsMatching("f", func() {}, `b`))
+	False(t, "PanicsMatching", a.PanicsMatching("f", func() {}, `(`))
+
+	StringEqual(t, "log", "\n"+string(b), `
+f does not panic as expected.
+panic value of f is expected to be 1(type=int), but got 1(type=int64)
+f is expected to panic with an error, but got "EOF"(type string)
+f is expected to panic with error matching *errors.errorString("EOF"), but got
+  *errors.errorString: "unexpected EOF"
+f is expected to panic with message matching /b/, but got "a"
+f does not panic as expected.
+assert: invalid pattern: error parsing regexp: missing closing ): `+"`(`"+`
+`)
+}
+
+func panicInHelper() {
+	panic(io.EOF)
+}
+
+func TestNotPanics(t *testing.T) {
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	False(t, "NotPanics", New(bt, FilePosition(false)).NotPanics("f", panicInHelper))
+
+	lines := strings.Split(string(b), "\n")
+	Equal(t, "first line", lines[0], `f panics unexpectedly with &errors.errorString{s: "EOF"}`)
+	Contains(t, "stack", string(b), "assert.panicInHelper()")
+}
@@ -0,0 +1,294 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// prettyConfig controls the layout of values printed by valuePrinter. Zero values mean no limits.
+type prettyConfig struct {
+	// Composite values whose single-line form is not longer than width are printed in a single
+	// line. If width is 0, all non-empty composite values are printed in multiple lines.
+	width int
+	// Maximum number of elements/entries of arrays, slices and maps to print.
+	maxElems int
+	// Maximum depth of nested composite values to print.
+	maxDepth int
+}
+
+// The config for values shown in failure messages.
+var messagePretty = prettyConfig{
+	width:    60,
+	maxElems: 20,
+	maxDepth: 8,
+}
+
+// prettyValue formats v for failure messages: type-annotated Go-literal-like representation with
+// pointers dereferenced, cycles marked, map keys sorted and huge values truncated. Strings are
+// never truncated, so that strings differing only near their ends are shown differently. The type
+// of a basic value, e.g. an int or a string, is not shown unless showType is true.
+func prettyValue(v reflect.Value, showType bool) string {
+	p := valuePrinter{config: messagePretty, visiting: make(map[uintptr]bool)}
+	return p.format(v, showType || !isBasicKind(v.Kind()), 0)
+}
+
+// formatValue serializes v into a deterministic, indented, Go-literal-like representation without
+// truncation. Map keys are sorted, pointers are dereferenced and cycles are marked instead of
+// printing addresses.
+func formatValue(v reflect.Value) string {
+	p := valuePrinter{visiting: make(map[uintptr]bool)}
+	return p.format(v, true, 0)
+}
+
+// showsType returns whether prettyValue(v, false) contains the type of v.
+func showsType(v reflect.Value) bool {
+	return v.IsValid() && !isBasicKind(v.Kind())
+}
+
+type valuePrinter struct {
+	config prettyConfig
+	// Addresses of pointers/maps/slices on the current path, for detecting cycles.
+	visiting map[uintptr]bool
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func isBasicKind(k reflect.Kind) bool {
+	return k >= reflect.Bool && k <= reflect.Complex128 || k == reflect.String
+}
+
+// scalar formats a basic value. The type is omitted if it is the default type of the literal.
+func scalar(t reflect.Type, showType bool, lit string, defaultType bool) string {
+	if !showType || defaultType && t.PkgPath() == "" {
+		return lit
+	}
+	return t.String() + "(" + lit + ")"
+}
+
+func (p *valuePrinter) format(v reflect.Value, showType bool, depth int) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	t := v.Type()
+	switch v.Kind() {
+	case reflect.Bool:
+		return scalar(t, showType, strconv.FormatBool(v.Bool()), t.Kind() == reflect.Bool)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return scalar(t, showType, strconv.FormatInt(v.Int(), 10), t.Kind() == reflect.Int)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return scalar(t, showType, strconv.FormatUint(v.Uint(), 10), false)
+	case reflect.Float32, reflect.Float64:
+		return scalar(t, showType, strconv.FormatFloat(v.Float(), 'g', -1, t.Bits()), t.Kind() == reflect.Float64)
+	case reflect.Complex64, reflect.Complex128:
+		return scalar(t, showType, strconv.FormatComplex(v.Complex(), 'g', -1, t.Bits()), t.Kind() == reflect.Complex128)
+	case reflect.String:
+		return scalar(t, showType, strconv.Quote(v.String()), t.Kind() == reflect.String)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if v.IsNil() {
+			return fmt.Sprintf("%s(nil)", t)
+		}
+		return fmt.Sprintf("%s(<non-nil>)", t)
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return p.format(v.Elem(), true, depth)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return fmt.Sprintf("(%s)(nil)", t)
+		}
+		if p.visiting[v.Pointer()] {
+			return "<cycle>"
+		}
+		p.visiting[v.Pointer()] = true
+		defer delete(p.visiting, v.Pointer())
+		if isBasicKind(v.Elem().Kind()) {
+			return fmt.Sprintf("&%s(%s)", v.Elem().Type(), p.format(v.Elem(), false, depth))
+		}
+		return "&" + p.format(v.Elem(), true, depth)
+	case reflect.Array:
+		return p.list(t, showType, v, depth)
+	case reflect.Slice:
+		if v.IsNil() {
+			return fmt.Sprintf("%s(nil)", t)
+		}
+		if v.Len() > 0 {
+			if p.visiting[v.Pointer()] {
+				return "<cycle>"
+			}
+			p.visiting[v.Pointer()] = true
+			defer delete(p.visiting, v.Pointer())
+		}
+		return p.list(t, showType, v, depth)
+	case reflect.Map:
+		if v.IsNil() {
+			return fmt.Sprintf("%s(nil)", t)
+		}
+		if p.visiting[v.Pointer()] {
+			return "<cycle>"
+		}
+		p.visiting[v.Pointer()] = true
+		defer delete(p.visiting, v.Pointer())
+		return p.mapEntries(t, showType, v, depth)
+	case reflect.Struct:
+		typ := ""
+		if showType {
+			typ = t.String()
+		}
+		if t == timeType && v.CanInterface() {
+			return typ + "(" + strconv.Quote(v.Interface().(time.Time).Format(time.RFC3339Nano)) + ")"
+		}
+		if p.tooDeep(depth) {
+			return typ + "{...}"
+		}
+		var items []string
+		for i := 0; i < t.NumField(); i++ {
+			items = append(items, t.Field(i).Name+": "+p.format(v.Field(i), true, depth+1))
+		}
+		return p.composite(typ, items)
+	}
+	return fmt.Sprintf("<%s>", t)
+}
+
+func (p *valuePrinter) tooDeep(depth int) bool {
+	return p.config.maxDepth > 0 && depth >= p.config.maxDepth
+}
+
+// composite joins the formatted items in a single line if possible, or in multiple lines
+// otherwise.
+func (p *valuePrinter) composite(typ string, items []string) string {
+	if len(items) == 0 {
+		return typ + "{}"
+	}
+	if p.config.width > 0 {
+		single := typ + "{" + strings.Join(items, ", ") + "}"
+		if len(single) <= p.config.width && !strings.ContainsRune(single, '\n') {
+			return single
+		}
+	}
+	var b strings.Builder
+	b.WriteString(typ + "{\n")
+	for _, item := range items {
+		b.WriteString("  " + strings.Replace(item, "\n", "\n  ", -1) + ",\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// moreItem returns the item replacing elements after the first maxElems ones of n elements.
+func (p *valuePrinter) moreItem(n int) string {
+	return fmt.Sprintf("...(%d more)", n-p.config.maxElems)
+}
+
+func (p *valuePrinter) list(t reflect.Type, showType bool, v reflect.Value, depth int) string {
+	typ := ""
+	if showType {
+		typ = t.String()
+	}
+	if p.tooDeep(depth) && v.Len() > 0 {
+		return typ + "{...}"
+	}
+	elemIsInterface := t.Elem().Kind() == reflect.Interface
+	var items []string
+	for i := 0; i < v.Len(); i++ {
+		if p.config.maxElems > 0 && i >= p.config.maxElems {
+			items = append(items, p.moreItem(v.Len()))
+			break
+		}
+		items = append(items, p.format(v.Index(i), elemIsInterface, depth+1))
+	}
+	return p.composite(typ, items)
+}
+
+// kindGroup returns the group of kinds whose values compareKeys compares, ordered by the returned
+// value. Other kinds are in the last group.
+func kindGroup(k reflect.Kind) int {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return 1
+	case reflect.Float32, reflect.Float64:
+		return 2
+	case reflect.String:
+		return 3
+	}
+	return 4
+}
+
+// compareKeys orders map keys, maybe in interfaces: ints, uints, floats and strings are ordered by
+// value, each group before the next one and before other kinds. It returns -1, 0 or 1, where 0
+// means the order is not decided, e.g. for other kinds, and is left to the formatted keys.
+func compareKeys(a, b reflect.Value) int {
+	if a.Kind() == reflect.Interface && !a.IsNil() {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface && !b.IsNil() {
+		b = b.Elem()
+	}
+	ga, gb := kindGroup(a.Kind()), kindGroup(b.Kind())
+	var less, greater bool
+	switch {
+	case ga != gb:
+		less, greater = ga < gb, ga > gb
+	case ga == 0:
+		less, greater = a.Int() < b.Int(), a.Int() > b.Int()
+	case ga == 1:
+		less, greater = a.Uint() < b.Uint(), a.Uint() > b.Uint()
+	case ga == 2:
+		less, greater = a.Float() < b.Float(), a.Float() > b.Float()
+	case ga == 3:
+		less, greater = a.String() < b.String(), a.String() > b.String()
+	}
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+func (p *valuePrinter) mapEntries(t reflect.Type, showType bool, v reflect.Value, depth int) string {
+	typ := ""
+	if showType {
+		typ = t.String()
+	}
+	if p.tooDeep(depth) && v.Len() > 0 {
+		return typ + "{...}"
+	}
+	type entry struct {
+		k   reflect.Value
+		key string
+		v   reflect.Value
+	}
+	keyIsInterface := t.Key().Kind() == reflect.Interface
+	elemIsInterface := t.Elem().Kind() == reflect.Interface
+	var entries []entry
+	for _, k := range v.MapKeys() {
+		entries = append(entries, entry{k: k, key: p.format(k, keyIsInterface, depth+1), v: v.MapIndex(k)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if cmp := compareKeys(entries[i].k, entries[j].k); cmp != 0 {
+			return cmp < 0
+		}
+		return entries[i].key < entries[j].key
+	})
+	var items []string
+	for i, e := range entries {
+		if p.config.maxElems > 0 && i >= p.config.maxElems {
+			items = append(items, p.moreItem(len(entries)))
+			break
+		}
+		items = append(items, e.key+": "+p.format(e.v, elemIsInterface, depth+1))
+	}
+	return p.composite(typ, items)
+}
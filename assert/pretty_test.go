@@ -0,0 +1,95 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func TestPrettyValue(t *testing.T) {
+	type Node struct {
+		Name     string
+		Children []*Node
+		Parent   *Node
+	}
+	root := &Node{Name: "root"}
+	root.Children = []*Node{{Name: "a", Parent: root}, {Name: "b", Parent: root}}
+
+	StringEqual(t, "prettyValue", prettyValue(reflect.ValueOf(root), false), `&assert.Node{
+  Name: "root",
+  Children: []*assert.Node{
+    &assert.Node{
+      Name: "a",
+      Children: []*assert.Node(nil),
+      Parent: <cycle>,
+    },
+    &assert.Node{
+      Name: "b",
+      Children: []*assert.Node(nil),
+      Parent: <cycle>,
+    },
+  },
+  Parent: (*assert.Node)(nil),
+}`)
+	StringEqual(t, "prettyValue", prettyValue(reflect.ValueOf(map[int8]interface{}{2: "b", 1: 1.5}), false),
+		`map[int8]interface {}{1: 1.5, 2: "b"}`)
+	StringEqual(t, "prettyValue", prettyValue(reflect.ValueOf(map[int]string{2: "a", 10: "b", 1: "c"}), false),
+		`map[int]string{1: "c", 2: "a", 10: "b"}`)
+	StringEqual(t, "prettyValue", prettyValue(reflect.ValueOf(map[float64]bool{-1.5: true, 10: false, 9: true}), false),
+		`map[float64]bool{-1.5: true, 9: true, 10: false}`)
+	StringEqual(t, "prettyValue", prettyValue(reflect.ValueOf(map[interface{}]int{"b": 1, 10: 2, 9: 3, "a": 4, 2.5: 5}), false),
+		`map[interface {}]int{9: 3, 10: 2, 2.5: 5, "a": 4, "b": 1}`)
+	StringEqual(t, "prettyValue", prettyValue(reflect.ValueOf(int8(1)), false), "1")
+	StringEqual(t, "prettyValue", prettyValue(reflect.ValueOf(int8(1)), true), "int8(1)")
+	x := 1
+	StringEqual(t, "prettyValue", prettyValue(reflect.ValueOf(&x), false), "&int(1)")
+
+	StringEqual(t, "prettyValue", prettyValue(reflect.ValueOf(make([]int, 25)), false),
+		"[]int{\n"+strings.Repeat("  0,\n", 20)+"  ...(5 more),\n}")
+	StringEqual(t, "prettyValue", prettyValue(reflect.ValueOf(strings.Repeat("é", 101)), false),
+		`"`+strings.Repeat("é", 101)+`"`)
+	type L struct {
+		Next interface{}
+	}
+	var deep interface{} = 1
+	for i := 0; i < 10; i++ {
+		deep = L{Next: deep}
+	}
+	ValueShould(t, "prettyValue", prettyValue(reflect.ValueOf(deep), false),
+		strings.Contains(prettyValue(reflect.ValueOf(deep), false), "assert.L{...}"), "is not truncated")
+}
+
+func TestDiffMessage_MultiLine(t *testing.T) {
+	type S struct {
+		Name, Description string
+	}
+	StringEqual(t, "diffMessage", diffMessage("v", reflect.ValueOf(&S{Name: "a long name", Description: "a long description"}), reflect.ValueOf((*S)(nil))), `v is expected to be
+  (*assert.S)(nil)
+but got
+  &assert.S{
+    Name: "a long name",
+    Description: "a long description",
+  }`)
+}
+
+func TestEqual_LongStrings(t *testing.T) {
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+
+	prefix := strings.Repeat("x", 250)
+	False(t, "return value", a.Equal("s", prefix+"a", prefix+"b"))
+	StringEqual(t, "log", "\n"+string(b), `
+s is expected to be
+  "`+prefix+`b"
+but got
+  "`+prefix+`a"
+`)
+}
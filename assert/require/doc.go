@@ -0,0 +1,14 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package require provides the assertions of package assert which stop the test, by t.FailNow(),
+if they fail.
+
+The functions are generated from the methods of assert.Assertions, i.e. require.Equal(t, ...) is
+the same as assert.Require(t).Equal(...).
+*/
+package require
+
+//go:generate go run gen.go
@@ -0,0 +1,217 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+// +build ignore
+
+// gen.go generates require.go from the methods of assert.Assertions.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const assertPath = "github.com/golangplus/testing/assert"
+
+type generator struct {
+	fset *token.FileSet
+	// Import paths of packages referenced by the signatures, by name.
+	imports map[string]string
+}
+
+// qualify rewrites a type expression in package assert so that it can be used in package require.
+func (g *generator) qualify(expr ast.Expr, fileImports map[string]string) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(e.Name) {
+			g.imports["assert"] = assertPath
+			return &ast.SelectorExpr{X: ast.NewIdent("assert"), Sel: e}
+		}
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			g.imports[x.Name] = fileImports[x.Name]
+		}
+	case *ast.StarExpr:
+		e.X = g.qualify(e.X, fileImports)
+	case *ast.ArrayType:
+		e.Elt = g.qualify(e.Elt, fileImports)
+	case *ast.MapType:
+		e.Key = g.qualify(e.Key, fileImports)
+		e.Value = g.qualify(e.Value, fileImports)
+	case *ast.ChanType:
+		e.Value = g.qualify(e.Value, fileImports)
+	case *ast.Ellipsis:
+		e.Elt = g.qualify(e.Elt, fileImports)
+	case *ast.FuncType:
+		for _, l := range []*ast.FieldList{e.Params, e.Results} {
+			if l == nil {
+				continue
+			}
+			for _, f := range l.List {
+				f.Type = g.qualify(f.Type, fileImports)
+			}
+		}
+	}
+	return expr
+}
+
+func (g *generator) exprString(expr ast.Expr) string {
+	var b bytes.Buffer
+	if err := printer.Fprint(&b, g.fset, expr); err != nil {
+		log.Fatal(err)
+	}
+	return b.String()
+}
+
+func isBool(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == "bool"
+}
+
+// wrapper returns the require function of an assertion method, or "" if the method is not an
+// assertion returning whether it holds, i.e. its results don't end with a bool. Methods like
+// ShouldOrDie are skipped in this way.
+func (g *generator) wrapper(fn *ast.FuncDecl, fileImports map[string]string) string {
+	var results []ast.Expr
+	if fn.Type.Results != nil {
+		for _, f := range fn.Type.Results.List {
+			n := len(f.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				results = append(results, f.Type)
+			}
+		}
+	}
+	if len(results) == 0 || !isBool(results[len(results)-1]) {
+		return ""
+	}
+	results = results[:len(results)-1]
+
+	params := []string{"t testing.TB"}
+	var args []string
+	for _, f := range fn.Type.Params.List {
+		typ := g.exprString(g.qualify(f.Type, fileImports))
+		var names []string
+		for _, n := range f.Names {
+			names = append(names, n.Name)
+			arg := n.Name
+			if _, ok := f.Type.(*ast.Ellipsis); ok {
+				arg += "..."
+			}
+			args = append(args, arg)
+		}
+		params = append(params, strings.Join(names, ", ")+" "+typ)
+	}
+	var resultTypes, resultVars []string
+	for i, r := range results {
+		resultTypes = append(resultTypes, g.exprString(g.qualify(r, fileImports)))
+		resultVars = append(resultVars, fmt.Sprintf("r%d", i))
+	}
+
+	var b bytes.Buffer
+	name := fn.Name.Name
+	fmt.Fprintf(&b, "// %s is the same as assert.%s, but stops the test if it fails.\n", name, name)
+	fmt.Fprintf(&b, "func %s(%s) ", name, strings.Join(params, ", "))
+	if len(resultTypes) > 0 {
+		fmt.Fprintf(&b, "(%s) ", strings.Join(resultTypes, ", "))
+	}
+	b.WriteString("{\n\tt.Helper()\n")
+	call := fmt.Sprintf("assert.Require(t).%s(%s)", name, strings.Join(args, ", "))
+	if len(resultVars) > 0 {
+		fmt.Fprintf(&b, "\t%s, _ := %s\n\treturn %s\n", strings.Join(resultVars, ", "), call, strings.Join(resultVars, ", "))
+	} else {
+		fmt.Fprintf(&b, "\t%s\n", call)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func main() {
+	g := &generator{fset: token.NewFileSet(), imports: map[string]string{"testing": "testing", "assert": assertPath}}
+	pkgs, err := parser.ParseDir(g.fset, "..", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	type decl struct {
+		pos  token.Position
+		code string
+	}
+	var decls []decl
+	for _, f := range pkgs["assert"].Files {
+		fileImports := make(map[string]string)
+		for _, imp := range f.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			name := path[strings.LastIndex(path, "/")+1:]
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			fileImports[name] = path
+		}
+		for _, d := range f.Decls {
+			fn, ok := d.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() {
+				continue
+			}
+			if star, ok := fn.Recv.List[0].Type.(*ast.StarExpr); !ok || star.X.(*ast.Ident).Name != "Assertions" {
+				continue
+			}
+			if code := g.wrapper(fn, fileImports); code != "" {
+				decls = append(decls, decl{pos: g.fset.Position(fn.Pos()), code: code})
+			}
+		}
+	}
+	sort.Slice(decls, func(i, j int) bool {
+		if decls[i].pos.Filename != decls[j].pos.Filename {
+			return decls[i].pos.Filename < decls[j].pos.Filename
+		}
+		return decls[i].pos.Line < decls[j].pos.Line
+	})
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen.go; DO NOT EDIT.\n\npackage require\n\nimport (\n")
+	var std, others []string
+	for _, path := range g.imports {
+		if strings.Contains(path, ".") {
+			others = append(others, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(others)
+	for _, path := range std {
+		fmt.Fprintf(&b, "\t%q\n", path)
+	}
+	b.WriteString("\n")
+	for _, path := range others {
+		fmt.Fprintf(&b, "\t%q\n", path)
+	}
+	b.WriteString(")\n")
+	for _, d := range decls {
+		b.WriteString("\n" + d.code)
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("%v\n%s", err, b.Bytes())
+	}
+	if err := ioutil.WriteFile("require.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
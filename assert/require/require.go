@@ -0,0 +1,414 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package require
+
+import (
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/golangplus/testing/assert"
+)
+
+// Equal is the same as assert.Equal, but stops the test if it fails.
+func Equal(t testing.TB, name string, act, exp interface{}) {
+	t.Helper()
+	assert.Require(t).Equal(name, act, exp)
+}
+
+// ValueShould is the same as assert.ValueShould, but stops the test if it fails.
+func ValueShould(t testing.TB, name string, act interface{}, expToFunc interface{}, descIfFailed string) {
+	t.Helper()
+	assert.Require(t).ValueShould(name, act, expToFunc, descIfFailed)
+}
+
+// NotEqual is the same as assert.NotEqual, but stops the test if it fails.
+func NotEqual(t testing.TB, name string, act, exp interface{}) {
+	t.Helper()
+	assert.Require(t).NotEqual(name, act, exp)
+}
+
+// False is the same as assert.False, but stops the test if it fails.
+func False(t testing.TB, name string, act bool) {
+	t.Helper()
+	assert.Require(t).False(name, act)
+}
+
+// True is the same as assert.True, but stops the test if it fails.
+func True(t testing.TB, name string, act bool) {
+	t.Helper()
+	assert.Require(t).True(name, act)
+}
+
+// Should is the same as assert.Should, but stops the test if it fails.
+func Should(t testing.TB, vl bool, showIfFailed string) {
+	t.Helper()
+	assert.Require(t).Should(vl, showIfFailed)
+}
+
+// StringEqual is the same as assert.StringEqual, but stops the test if it fails.
+func StringEqual(t testing.TB, name string, act, exp interface{}) {
+	t.Helper()
+	assert.Require(t).StringEqual(name, act, exp)
+}
+
+// NoError is the same as assert.NoError, but stops the test if it fails.
+func NoError(t testing.TB, err error) {
+	t.Helper()
+	assert.Require(t).NoError(err)
+}
+
+// Error is the same as assert.Error, but stops the test if it fails.
+func Error(t testing.TB, err error) {
+	t.Helper()
+	assert.Require(t).Error(err)
+}
+
+// Panic is the same as assert.Panic, but stops the test if it fails.
+func Panic(t testing.TB, name string, f func()) {
+	t.Helper()
+	assert.Require(t).Panic(name, f)
+}
+
+// Receives is the same as assert.Receives, but stops the test if it fails.
+func Receives(t testing.TB, name string, ch interface{}, timeout time.Duration) interface{} {
+	t.Helper()
+	r0, _ := assert.Require(t).Receives(name, ch, timeout)
+	return r0
+}
+
+// ReceivesEqual is the same as assert.ReceivesEqual, but stops the test if it fails.
+func ReceivesEqual(t testing.TB, name string, ch interface{}, exp interface{}, timeout time.Duration) {
+	t.Helper()
+	assert.Require(t).ReceivesEqual(name, ch, exp, timeout)
+}
+
+// NotReceives is the same as assert.NotReceives, but stops the test if it fails.
+func NotReceives(t testing.TB, name string, ch interface{}, timeout time.Duration) {
+	t.Helper()
+	assert.Require(t).NotReceives(name, ch, timeout)
+}
+
+// Closed is the same as assert.Closed, but stops the test if it fails.
+func Closed(t testing.TB, name string, ch interface{}, timeout time.Duration) {
+	t.Helper()
+	assert.Require(t).Closed(name, ch, timeout)
+}
+
+// Contains is the same as assert.Contains, but stops the test if it fails.
+func Contains(t testing.TB, name string, container, elem interface{}) {
+	t.Helper()
+	assert.Require(t).Contains(name, container, elem)
+}
+
+// NotContains is the same as assert.NotContains, but stops the test if it fails.
+func NotContains(t testing.TB, name string, container, elem interface{}) {
+	t.Helper()
+	assert.Require(t).NotContains(name, container, elem)
+}
+
+// Subset is the same as assert.Subset, but stops the test if it fails.
+func Subset(t testing.TB, name string, act, super interface{}) {
+	t.Helper()
+	assert.Require(t).Subset(name, act, super)
+}
+
+// Superset is the same as assert.Superset, but stops the test if it fails.
+func Superset(t testing.TB, name string, act, sub interface{}) {
+	t.Helper()
+	assert.Require(t).Superset(name, act, sub)
+}
+
+// HasKey is the same as assert.HasKey, but stops the test if it fails.
+func HasKey(t testing.TB, name string, m, key interface{}) {
+	t.Helper()
+	assert.Require(t).HasKey(name, m, key)
+}
+
+// Len is the same as assert.Len, but stops the test if it fails.
+func Len(t testing.TB, name string, act interface{}, n int) {
+	t.Helper()
+	assert.Require(t).Len(name, act, n)
+}
+
+// Empty is the same as assert.Empty, but stops the test if it fails.
+func Empty(t testing.TB, name string, act interface{}) {
+	t.Helper()
+	assert.Require(t).Empty(name, act)
+}
+
+// NotEmpty is the same as assert.NotEmpty, but stops the test if it fails.
+func NotEmpty(t testing.TB, name string, act interface{}) {
+	t.Helper()
+	assert.Require(t).NotEmpty(name, act)
+}
+
+// ErrorIs is the same as assert.ErrorIs, but stops the test if it fails.
+func ErrorIs(t testing.TB, err, target error) {
+	t.Helper()
+	assert.Require(t).ErrorIs(err, target)
+}
+
+// ErrorAs is the same as assert.ErrorAs, but stops the test if it fails.
+func ErrorAs(t testing.TB, err error, target interface{}) {
+	t.Helper()
+	assert.Require(t).ErrorAs(err, target)
+}
+
+// ErrorContains is the same as assert.ErrorContains, but stops the test if it fails.
+func ErrorContains(t testing.TB, err error, substr string) {
+	t.Helper()
+	assert.Require(t).ErrorContains(err, substr)
+}
+
+// ErrorMatches is the same as assert.ErrorMatches, but stops the test if it fails.
+func ErrorMatches(t testing.TB, err error, pattern string) {
+	t.Helper()
+	assert.Require(t).ErrorMatches(err, pattern)
+}
+
+// Eventually is the same as assert.Eventually, but stops the test if it fails.
+func Eventually(t testing.TB, name string, cond interface{}, timeout, interval time.Duration) {
+	t.Helper()
+	assert.Require(t).Eventually(name, cond, timeout, interval)
+}
+
+// Never is the same as assert.Never, but stops the test if it fails.
+func Never(t testing.TB, name string, cond interface{}, timeout, interval time.Duration) {
+	t.Helper()
+	assert.Require(t).Never(name, cond, timeout, interval)
+}
+
+// Consistently is the same as assert.Consistently, but stops the test if it fails.
+func Consistently(t testing.TB, name string, cond interface{}, timeout, interval time.Duration) {
+	t.Helper()
+	assert.Require(t).Consistently(name, cond, timeout, interval)
+}
+
+// FileExists is the same as assert.FileExists, but stops the test if it fails.
+func FileExists(t testing.TB, name, path string) {
+	t.Helper()
+	assert.Require(t).FileExists(name, path)
+}
+
+// DirExists is the same as assert.DirExists, but stops the test if it fails.
+func DirExists(t testing.TB, name, path string) {
+	t.Helper()
+	assert.Require(t).DirExists(name, path)
+}
+
+// FileContent is the same as assert.FileContent, but stops the test if it fails.
+func FileContent(t testing.TB, name, path, exp string) {
+	t.Helper()
+	assert.Require(t).FileContent(name, path, exp)
+}
+
+// DirEqual is the same as assert.DirEqual, but stops the test if it fails.
+func DirEqual(t testing.TB, name, act, exp string) {
+	t.Helper()
+	assert.Require(t).DirEqual(name, act, exp)
+}
+
+// FSEqual is the same as assert.FSEqual, but stops the test if it fails.
+func FSEqual(t testing.TB, name string, act, exp fs.FS) {
+	t.Helper()
+	assert.Require(t).FSEqual(name, act, exp)
+}
+
+// JSONEqual is the same as assert.JSONEqual, but stops the test if it fails.
+func JSONEqual(t testing.TB, name string, act, exp interface{}, opts ...assert.CompareOption) {
+	t.Helper()
+	assert.Require(t).JSONEqual(name, act, exp, opts...)
+}
+
+// Matches is the same as assert.Matches, but stops the test if it fails.
+func Matches(t testing.TB, name string, act interface{}, pattern string) {
+	t.Helper()
+	assert.Require(t).Matches(name, act, pattern)
+}
+
+// NotMatches is the same as assert.NotMatches, but stops the test if it fails.
+func NotMatches(t testing.TB, name string, act interface{}, pattern string) {
+	t.Helper()
+	assert.Require(t).NotMatches(name, act, pattern)
+}
+
+// MatchesGlob is the same as assert.MatchesGlob, but stops the test if it fails.
+func MatchesGlob(t testing.TB, name string, act interface{}, glob string) {
+	t.Helper()
+	assert.Require(t).MatchesGlob(name, act, glob)
+}
+
+// NotMatchesGlob is the same as assert.NotMatchesGlob, but stops the test if it fails.
+func NotMatchesGlob(t testing.TB, name string, act interface{}, glob string) {
+	t.Helper()
+	assert.Require(t).NotMatchesGlob(name, act, glob)
+}
+
+// MatchesLines is the same as assert.MatchesLines, but stops the test if it fails.
+func MatchesLines(t testing.TB, name string, act interface{}, checks string) {
+	t.Helper()
+	assert.Require(t).MatchesLines(name, act, checks)
+}
+
+// Less is the same as assert.Less, but stops the test if it fails.
+func Less(t testing.TB, name string, act, than interface{}) {
+	t.Helper()
+	assert.Require(t).Less(name, act, than)
+}
+
+// LessOrEqual is the same as assert.LessOrEqual, but stops the test if it fails.
+func LessOrEqual(t testing.TB, name string, act, than interface{}) {
+	t.Helper()
+	assert.Require(t).LessOrEqual(name, act, than)
+}
+
+// Greater is the same as assert.Greater, but stops the test if it fails.
+func Greater(t testing.TB, name string, act, than interface{}) {
+	t.Helper()
+	assert.Require(t).Greater(name, act, than)
+}
+
+// GreaterOrEqual is the same as assert.GreaterOrEqual, but stops the test if it fails.
+func GreaterOrEqual(t testing.TB, name string, act, than interface{}) {
+	t.Helper()
+	assert.Require(t).GreaterOrEqual(name, act, than)
+}
+
+// Between is the same as assert.Between, but stops the test if it fails.
+func Between(t testing.TB, name string, act, lo, hi interface{}) {
+	t.Helper()
+	assert.Require(t).Between(name, act, lo, hi)
+}
+
+// IsSorted is the same as assert.IsSorted, but stops the test if it fails.
+func IsSorted(t testing.TB, name string, s interface{}) {
+	t.Helper()
+	assert.Require(t).IsSorted(name, s)
+}
+
+// IsSortedFunc is the same as assert.IsSortedFunc, but stops the test if it fails.
+func IsSortedFunc(t testing.TB, name string, s interface{}, less interface{}) {
+	t.Helper()
+	assert.Require(t).IsSortedFunc(name, s, less)
+}
+
+// PanicsWithValue is the same as assert.PanicsWithValue, but stops the test if it fails.
+func PanicsWithValue(t testing.TB, name string, f func(), value interface{}) {
+	t.Helper()
+	assert.Require(t).PanicsWithValue(name, f, value)
+}
+
+// PanicsWithError is the same as assert.PanicsWithError, but stops the test if it fails.
+func PanicsWithError(t testing.TB, name string, f func(), target error) {
+	t.Helper()
+	assert.Require(t).PanicsWithError(name, f, target)
+}
+
+// PanicsMatching is the same as assert.PanicsMatching, but stops the test if it fails.
+func PanicsMatching(t testing.TB, name string, f func(), pattern string) {
+	t.Helper()
+	assert.Require(t).PanicsMatching(name, f, pattern)
+}
+
+// NotPanics is the same as assert.NotPanics, but stops the test if it fails.
+func NotPanics(t testing.TB, name string, f func()) {
+	t.Helper()
+	assert.Require(t).NotPanics(name, f)
+}
+
+// Snapshot is the same as assert.Snapshot, but stops the test if it fails.
+func Snapshot(t testing.TB, name string, act interface{}) {
+	t.Helper()
+	assert.Require(t).Snapshot(name, act)
+}
+
+// TimeEqual is the same as assert.TimeEqual, but stops the test if it fails.
+func TimeEqual(t testing.TB, name string, act, exp time.Time) {
+	t.Helper()
+	assert.Require(t).TimeEqual(name, act, exp)
+}
+
+// WithinDuration is the same as assert.WithinDuration, but stops the test if it fails.
+func WithinDuration(t testing.TB, name string, act, exp time.Time, delta time.Duration) {
+	t.Helper()
+	assert.Require(t).WithinDuration(name, act, exp, delta)
+}
+
+// Before is the same as assert.Before, but stops the test if it fails.
+func Before(t testing.TB, name string, act, than time.Time) {
+	t.Helper()
+	assert.Require(t).Before(name, act, than)
+}
+
+// After is the same as assert.After, but stops the test if it fails.
+func After(t testing.TB, name string, act, than time.Time) {
+	t.Helper()
+	assert.Require(t).After(name, act, than)
+}
+
+// DurationWithin is the same as assert.DurationWithin, but stops the test if it fails.
+func DurationWithin(t testing.TB, name string, act, exp, tolerance time.Duration) {
+	t.Helper()
+	assert.Require(t).DurationWithin(name, act, exp, tolerance)
+}
+
+// TOMLEqual is the same as assert.TOMLEqual, but stops the test if it fails.
+func TOMLEqual(t testing.TB, name string, act, exp interface{}, opts ...assert.CompareOption) {
+	t.Helper()
+	assert.Require(t).TOMLEqual(name, act, exp, opts...)
+}
+
+// IsType is the same as assert.IsType, but stops the test if it fails.
+func IsType(t testing.TB, name string, act, exp interface{}) {
+	t.Helper()
+	assert.Require(t).IsType(name, act, exp)
+}
+
+// Implements is the same as assert.Implements, but stops the test if it fails.
+func Implements(t testing.TB, name string, act, iface interface{}) {
+	t.Helper()
+	assert.Require(t).Implements(name, act, iface)
+}
+
+// Nil is the same as assert.Nil, but stops the test if it fails.
+func Nil(t testing.TB, name string, act interface{}) {
+	t.Helper()
+	assert.Require(t).Nil(name, act)
+}
+
+// NotNil is the same as assert.NotNil, but stops the test if it fails.
+func NotNil(t testing.TB, name string, act interface{}) {
+	t.Helper()
+	assert.Require(t).NotNil(name, act)
+}
+
+// Zero is the same as assert.Zero, but stops the test if it fails.
+func Zero(t testing.TB, name string, act interface{}) {
+	t.Helper()
+	assert.Require(t).Zero(name, act)
+}
+
+// NotZero is the same as assert.NotZero, but stops the test if it fails.
+func NotZero(t testing.TB, name string, act interface{}) {
+	t.Helper()
+	assert.Require(t).NotZero(name, act)
+}
+
+// XMLEqual is the same as assert.XMLEqual, but stops the test if it fails.
+func XMLEqual(t testing.TB, name string, act, exp interface{}, opts ...assert.MarkupOption) {
+	t.Helper()
+	assert.Require(t).XMLEqual(name, act, exp, opts...)
+}
+
+// HTMLEqual is the same as assert.HTMLEqual, but stops the test if it fails.
+func HTMLEqual(t testing.TB, name string, act, exp interface{}, opts ...assert.MarkupOption) {
+	t.Helper()
+	assert.Require(t).HTMLEqual(name, act, exp, opts...)
+}
+
+// YAMLEqual is the same as assert.YAMLEqual, but stops the test if it fails.
+func YAMLEqual(t testing.TB, name string, act, exp interface{}, opts ...assert.CompareOption) {
+	t.Helper()
+	assert.Require(t).YAMLEqual(name, act, exp, opts...)
+}
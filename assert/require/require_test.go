@@ -0,0 +1,57 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package require
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+	"github.com/golangplus/testing/assert"
+)
+
+func TestRequire(t *testing.T) {
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+
+	Equal(bt, "v", 1, 1)
+	assert.False(t, "failed", bt.Failed())
+	assert.True(t, "panic", assert.Panic(t, "Equal", func() {
+		Equal(bt, "v", 1, 2)
+	}))
+	assert.True(t, "failed", bt.Failed())
+	assert.True(t, "panic", assert.Panic(t, "NoError", func() {
+		NoError(bt, errors.New("failed"))
+	}))
+}
+
+// TestGenerated checks that require.go is generated from the current assert.Assertions.
+func TestGenerated(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "require.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	funcs := make(map[string]bool)
+	for _, d := range f.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok {
+			funcs[fn.Name.Name] = true
+		}
+	}
+	typ := reflect.TypeOf(&assert.Assertions{})
+	for i := 0; i < typ.NumMethod(); i++ {
+		m := typ.Method(i)
+		if m.Type.NumOut() == 0 || m.Type.Out(m.Type.NumOut()-1).Kind() != reflect.Bool {
+			continue
+		}
+		assert.True(t, m.Name+" generated, run go generate if not", funcs[m.Name])
+		delete(funcs, m.Name)
+	}
+	assert.Equal(t, "obsolete functions, run go generate to remove", funcs, map[string]bool{})
+}
@@ -0,0 +1,221 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// The directory where snapshot files are stored, relative to the working directory of the test.
+var SnapshotDir = filepath.Join("testdata", "snapshots")
+
+// Set this to true to (re)write snapshots instead of comparing with them. It is initialized to true
+// if the environment variable UPDATE_SNAPSHOTS is not empty.
+var UpdateSnapshots = os.Getenv("UPDATE_SNAPSHOTS") != ""
+
+type snapshotEntry struct {
+	name  string
+	value string
+}
+
+type snapshotFile struct {
+	entries []snapshotEntry
+	// Whether entries were read from the file(false) or recorded in this run(true).
+	recorded bool
+}
+
+func (f *snapshotFile) find(name string) (string, bool) {
+	for _, e := range f.entries {
+		if e.name == name {
+			return e.value, true
+		}
+	}
+	return "", false
+}
+
+func (f *snapshotFile) set(name, value string) {
+	for i := range f.entries {
+		if f.entries[i].name == name {
+			f.entries[i].value = value
+			return
+		}
+	}
+	f.entries = append(f.entries, snapshotEntry{name: name, value: value})
+}
+
+// snapshots caches snapshot files by path. All files touched in this run are kept here so that
+// PruneSnapshots knows which ones are still in use.
+var snapshots = struct {
+	sync.Mutex
+	files map[string]*snapshotFile
+}{files: make(map[string]*snapshotFile)}
+
+func snapshotPath(testName string) string {
+	name := strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		}
+		return r
+	}, testName)
+	return filepath.Join(SnapshotDir, name+".snap")
+}
+
+func snapshotHeader(name string) string {
+	return "-- " + name + " --"
+}
+
+// readSnapshotFile parses a snapshot file. The format is similar to txtar: each entry starts with a
+// line of "-- name --", followed by the serialized value.
+func readSnapshotFile(path string) (*snapshotFile, error) {
+	f := &snapshotFile{}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cur *snapshotEntry
+	var lines []string
+	flush := func() {
+		if cur != nil {
+			cur.value = strings.Join(lines, "\n")
+			f.entries = append(f.entries, *cur)
+		}
+		lines = nil
+	}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(nil, len(data)+1)
+	for s.Scan() {
+		line := s.Text()
+		if strings.HasPrefix(line, "-- ") && strings.HasSuffix(line, " --") && len(line) >= 6 {
+			flush()
+			cur = &snapshotEntry{name: line[3 : len(line)-3]}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return f, s.Err()
+}
+
+func writeSnapshotFile(path string, f *snapshotFile) error {
+	var b bytes.Buffer
+	for _, e := range f.entries {
+		b.WriteString(snapshotHeader(e.name))
+		b.WriteByte('\n')
+		b.WriteString(e.value)
+		b.WriteByte('\n')
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b.Bytes(), 0644)
+}
+
+// Snapshot compares the serialized act with the snapshot named name of the current test. The
+// snapshots of a test are stored in a file under SnapshotDir named after t.Name().
+//
+// If UpdateSnapshots is true, the snapshot is written instead, and snapshots of the test that are
+// not written in this run are removed from the file.
+func Snapshot(t testing.TB, name string, act interface{}) bool {
+	t.Helper()
+	return New(t).Snapshot(name, act)
+}
+
+// Snapshot is the same as the package-level Snapshot.
+func (a *Assertions) Snapshot(name string, act interface{}) bool {
+	a.t.Helper()
+	actS := formatValue(reflect.ValueOf(act))
+	path := snapshotPath(a.t.Name())
+
+	snapshots.Lock()
+	defer snapshots.Unlock()
+
+	f := snapshots.files[path]
+	if UpdateSnapshots {
+		if f == nil || !f.recorded {
+			f = &snapshotFile{recorded: true}
+			snapshots.files[path] = f
+		}
+		f.set(name, actS)
+		if err := writeSnapshotFile(path, f); err != nil {
+			a.fail(fmt.Sprintf("assert: failed to write snapshot %s: %v", name, err))
+			return false
+		}
+		return true
+	}
+
+	if f == nil {
+		var err error
+		if f, err = readSnapshotFile(path); err != nil {
+			a.fail(fmt.Sprintf("assert: failed to read snapshot %s: %v", name, err))
+			return false
+		}
+		snapshots.files[path] = f
+	}
+	expS, ok := f.find(name)
+	if !ok {
+		a.fail(fmt.Sprintf("snapshot %s not found in %s, run the test with UPDATE_SNAPSHOTS=1 to create it", name, path))
+		return false
+	}
+	if actS == expS {
+		return true
+	}
+	return a.linesEqual(name,
+		reflect.ValueOf(strings.Split(actS, "\n")),
+		reflect.ValueOf(strings.Split(expS, "\n")))
+}
+
+// PruneSnapshots removes snapshot files under SnapshotDir that were not used in this run. It is
+// intended to be called in TestMain after m.Run(). It does nothing unless UpdateSnapshots is true
+// and all tests were selected, i.e. neither -test.run nor -test.skip is set, so that snapshots of
+// tests which did not run are kept.
+func PruneSnapshots() error {
+	if !UpdateSnapshots || !allTestsSelected() {
+		return nil
+	}
+	return pruneSnapshots()
+}
+
+// allTestsSelected returns whether no flag of the testing package filters the tests to run.
+func allTestsSelected() bool {
+	for _, name := range []string{"test.run", "test.skip"} {
+		if f := flag.Lookup(name); f != nil && f.Value.String() != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func pruneSnapshots() error {
+	snapshots.Lock()
+	defer snapshots.Unlock()
+
+	matches, err := filepath.Glob(filepath.Join(SnapshotDir, "*.snap"))
+	if err != nil {
+		return err
+	}
+	for _, path := range matches {
+		if _, ok := snapshots.files[path]; ok {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
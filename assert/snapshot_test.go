@@ -0,0 +1,96 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func TestFormatValue(t *testing.T) {
+	type Node struct {
+		V    int
+		Next *Node
+		m    map[string]interface{}
+	}
+	n := &Node{V: 1, m: map[string]interface{}{"b": int8(2), "a": "x"}}
+	n.Next = n
+
+	StringEqual(t, "formatValue", formatValue(reflect.ValueOf(n)), `&assert.Node{
+  V: 1,
+  Next: <cycle>,
+  m: map[string]interface {}{
+    "a": "x",
+    "b": int8(2),
+  },
+}`)
+	StringEqual(t, "formatValue", formatValue(reflect.ValueOf([]int(nil))), "[]int(nil)")
+	StringEqual(t, "formatValue", formatValue(reflect.ValueOf(nil)), "nil")
+}
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	NoErrorOrDie(t, err)
+	defer os.RemoveAll(dir)
+
+	defer func(d string, u bool) { SnapshotDir, UpdateSnapshots = d, u }(SnapshotDir, UpdateSnapshots)
+	SnapshotDir = dir
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b, TestName: "TestA/sub"}
+	a := New(bt, FilePosition(false))
+
+	UpdateSnapshots = true
+	True(t, "update", a.Snapshot("v", map[string]int{"b": 2, "a": 1}))
+	True(t, "update", a.Snapshot("s", "str"))
+	content, err := ioutil.ReadFile(filepath.Join(dir, "TestA_sub.snap"))
+	NoErrorOrDie(t, err)
+	StringEqual(t, "content", string(content), `-- v --
+map[string]int{
+  "a": 1,
+  "b": 2,
+}
+-- s --
+"str"
+`)
+
+	UpdateSnapshots = false
+	resetSnapshots()
+	True(t, "match", a.Snapshot("v", map[string]int{"a": 1, "b": 2}))
+	False(t, "match", a.Snapshot("v", map[string]int{"a": 1, "b": 3}))
+	False(t, "missing", a.Snapshot("none", 1))
+	StringEqual(t, "log", "\n"+string(b), `
+Unexpected v: both 4 lines
+  Difference(expected ---  actual +++)
+    ---   3: "  \"b\": 2,"
+    +++   3: "  \"b\": 3,"
+snapshot none not found in `+filepath.Join(dir, "TestA_sub.snap")+`, run the test with UPDATE_SNAPSHOTS=1 to create it
+`)
+
+	NoErrorOrDie(t, ioutil.WriteFile(filepath.Join(dir, "Obsolete.snap"), nil, 0644))
+	NoError(t, PruneSnapshots())
+	matches, err := filepath.Glob(filepath.Join(dir, "*.snap"))
+	NoError(t, err)
+	Equal(t, "kept matches", matches, []string{filepath.Join(dir, "Obsolete.snap"), filepath.Join(dir, "TestA_sub.snap")})
+
+	NoError(t, pruneSnapshots())
+	matches, err = filepath.Glob(filepath.Join(dir, "*.snap"))
+	NoError(t, err)
+	Equal(t, "matches", matches, []string{filepath.Join(dir, "TestA_sub.snap")})
+}
+
+func resetSnapshots() {
+	snapshots.Lock()
+	defer snapshots.Unlock()
+	for path := range snapshots.files {
+		delete(snapshots.files, path)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// formatDelta formats a signed difference, e.g. "+1.5s" or "-2h0m0s".
+func formatDelta(d time.Duration) string {
+	if d > 0 {
+		return "+" + d.String()
+	}
+	return d.String()
+}
+
+// The bounds time.Time.Sub clamps differences to when they overflow a time.Duration.
+const (
+	minDuration time.Duration = math.MinInt64
+	maxDuration time.Duration = math.MaxInt64
+)
+
+// withinDelta returns whether -delta <= d <= delta, where d is not a clamped or overflown
+// difference. It doesn't negate d, which overflows for minDuration.
+func withinDelta(d, delta time.Duration) bool {
+	return d != minDuration && d != maxDuration && d >= -delta && d <= delta
+}
+
+// subDurations returns act - exp, clamped to minDuration or maxDuration on overflow as
+// time.Time.Sub does.
+func subDurations(act, exp time.Duration) time.Duration {
+	d := act - exp
+	switch {
+	case exp < 0 && d < act:
+		return maxDuration
+	case exp > 0 && d > act:
+		return minDuration
+	}
+	return d
+}
+
+// TimeEqual checks whether act and exp are the same instant, by time.Time.Equal, regardless of
+// their locations and monotonic clock readings, which Equal compares.
+func TimeEqual(t testing.TB, name string, act, exp time.Time) bool {
+	t.Helper()
+	return New(t).TimeEqual(name, act, exp)
+}
+
+// TimeEqual is the same as the package-level TimeEqual.
+func (a *Assertions) TimeEqual(name string, act, exp time.Time) bool {
+	a.t.Helper()
+	if act.Equal(exp) {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is expected to be %s, but got %s (delta %s)", name, formatTime(exp), formatTime(act), formatDelta(act.Sub(exp))))
+	return false
+}
+
+// WithinDuration checks whether act is within delta of exp, i.e. |act - exp| <= delta.
+func WithinDuration(t testing.TB, name string, act, exp time.Time, delta time.Duration) bool {
+	t.Helper()
+	return New(t).WithinDuration(name, act, exp, delta)
+}
+
+// WithinDuration is the same as the package-level WithinDuration.
+func (a *Assertions) WithinDuration(name string, act, exp time.Time, delta time.Duration) bool {
+	a.t.Helper()
+	d := act.Sub(exp)
+	if withinDelta(d, delta) {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is expected to be within %v of %s, but got %s (delta %s)", name, delta, formatTime(exp), formatTime(act), formatDelta(d)))
+	return false
+}
+
+// Before checks whether act is before than.
+func Before(t testing.TB, name string, act, than time.Time) bool {
+	t.Helper()
+	return New(t).Before(name, act, than)
+}
+
+// Before is the same as the package-level Before.
+func (a *Assertions) Before(name string, act, than time.Time) bool {
+	a.t.Helper()
+	if act.Before(than) {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is expected to be before %s, but got %s (delta %s)", name, formatTime(than), formatTime(act), formatDelta(act.Sub(than))))
+	return false
+}
+
+// After checks whether act is after than.
+func After(t testing.TB, name string, act, than time.Time) bool {
+	t.Helper()
+	return New(t).After(name, act, than)
+}
+
+// After is the same as the package-level After.
+func (a *Assertions) After(name string, act, than time.Time) bool {
+	a.t.Helper()
+	if act.After(than) {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is expected to be after %s, but got %s (delta %s)", name, formatTime(than), formatTime(act), formatDelta(act.Sub(than))))
+	return false
+}
+
+// DurationWithin checks whether act is within tolerance of exp, i.e. |act - exp| <= tolerance.
+func DurationWithin(t testing.TB, name string, act, exp, tolerance time.Duration) bool {
+	t.Helper()
+	return New(t).DurationWithin(name, act, exp, tolerance)
+}
+
+// DurationWithin is the same as the package-level DurationWithin.
+func (a *Assertions) DurationWithin(name string, act, exp, tolerance time.Duration) bool {
+	a.t.Helper()
+	d := subDurations(act, exp)
+	if withinDelta(d, tolerance) {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is expected to be within %v of %v, but got %v (delta %s)", name, tolerance, exp, act, formatDelta(d)))
+	return false
+}
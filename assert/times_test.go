@@ -0,0 +1,67 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func ExampleWithinDuration() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
+
+	exp := time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC)
+	a.WithinDuration("updated", exp.Add(1500*time.Millisecond), exp, time.Second)
+
+	// OUTPUT:
+	// updated is expected to be within 1s of 2015-01-02T03:04:05Z, but got 2015-01-02T03:04:06.5Z (delta +1.5s)
+}
+
+func TestTimeEqual(t *testing.T) {
+	tm := time.Date(2015, 1, 2, 3, 4, 5, 6, time.UTC)
+	True(t, "TimeEqual", TimeEqual(t, "v", tm.In(time.FixedZone("X", 3600)), tm))
+	now := time.Now()
+	True(t, "TimeEqual", TimeEqual(t, "v", now, now.Round(0)))
+	True(t, "WithinDuration", WithinDuration(t, "v", tm, tm.Add(time.Second), time.Second))
+	True(t, "Before", Before(t, "v", tm, tm.Add(1)))
+	True(t, "After", After(t, "v", tm, tm.Add(-1)))
+	True(t, "DurationWithin", DurationWithin(t, "v", 990*time.Millisecond, time.Second, 10*time.Millisecond))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "TimeEqual", a.TimeEqual("v", tm.Add(time.Hour).In(time.FixedZone("X", 3600)), tm))
+	False(t, "WithinDuration", a.WithinDuration("v", tm, tm.Add(time.Minute), time.Second))
+	False(t, "Before", a.Before("v", tm, tm))
+	False(t, "After", a.After("v", tm, tm.Add(time.Millisecond)))
+	False(t, "DurationWithin", a.DurationWithin("v", 2*time.Second, time.Second, 10*time.Millisecond))
+
+	StringEqual(t, "log", "\n"+string(b), `
+v is expected to be 2015-01-02T03:04:05.000000006Z, but got 2015-01-02T05:04:05.000000006+01:00 (delta +1h0m0s)
+v is expected to be within 1s of 2015-01-02T03:05:05.000000006Z, but got 2015-01-02T03:04:05.000000006Z (delta -1m0s)
+v is expected to be before 2015-01-02T03:04:05.000000006Z, but got 2015-01-02T03:04:05.000000006Z (delta 0s)
+v is expected to be after 2015-01-02T03:04:05.001000006Z, but got 2015-01-02T03:04:05.000000006Z (delta -1ms)
+v is expected to be within 10ms of 1s, but got 2s (delta +1s)
+`)
+}
+
+func TestWithinDuration_Overflow(t *testing.T) {
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	// The difference overflows a time.Duration and is clamped by time.Time.Sub.
+	False(t, "WithinDuration", a.WithinDuration("v", time.Time{}, time.Now(), time.Second))
+	False(t, "WithinDuration", a.WithinDuration("v", time.Now(), time.Time{}, time.Second))
+	False(t, "DurationWithin", a.DurationWithin("v", math.MinInt64+1, 2, time.Second))
+	False(t, "DurationWithin", a.DurationWithin("v", math.MaxInt64, -1, time.Second))
+	True(t, "failed", bt.Failed())
+}
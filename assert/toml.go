@@ -0,0 +1,62 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+func parseTOML(v interface{}) (interface{}, error) {
+	var data string
+	switch v := v.(type) {
+	case string:
+		data = v
+	case []byte:
+		data = string(v)
+	default:
+		var b bytes.Buffer
+		if err := toml.NewEncoder(&b).Encode(v); err != nil {
+			return nil, err
+		}
+		data = b.String()
+	}
+	var doc map[string]interface{}
+	if _, err := toml.Decode(data, &doc); err != nil {
+		return nil, err
+	}
+	return normalizeDocument(doc), nil
+}
+
+// TOMLEqual compares two TOML documents semantically, i.e. the order of keys and tables and
+// formatting are ignored. act and exp could be a string, a []byte or any value that is encoded with
+// github.com/BurntSushi/toml first. Differences are reported with paths like "config/server/port".
+func TOMLEqual(t testing.TB, name string, act, exp interface{}, opts ...CompareOption) bool {
+	t.Helper()
+	return New(t).TOMLEqual(name, act, exp, opts...)
+}
+
+// TOMLEqual is the same as the package-level TOMLEqual.
+func (a *Assertions) TOMLEqual(name string, act, exp interface{}, opts ...CompareOption) bool {
+	a.t.Helper()
+	actDoc, err := parseTOML(act)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s is not valid TOML: %v", name, err))
+		return false
+	}
+	expDoc, err := parseTOML(exp)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: expected %s is not valid TOML: %v", name, err))
+		return false
+	}
+	if m, eq := documentDiff(name, actDoc, expDoc, a.compareOptions(opts)); !eq {
+		a.fail(m)
+		return false
+	}
+	return true
+}
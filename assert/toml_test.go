@@ -0,0 +1,38 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func TestTOMLEqual(t *testing.T) {
+	True(t, "return value", TOMLEqual(t, "v", `
+title = "x"
+[server]
+port = 80
+[[items]]
+id = 1
+`, `
+items = [{id = 1.0}]
+server = {port = 80}
+title = 'x'
+`))
+	True(t, "return value", TOMLEqual(t, "v", map[string]int{"a": 1}, "a = 1"))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+
+	False(t, "return value", a.TOMLEqual("v", "[server]\nport = 80\nhost = 'a'", "[server]\nport = 8080"))
+	StringEqual(t, "output", "\n"+string(b), `
+v is unexpected:
+  extra v/server/host -> "a"
+  v/server/port is expected to be 8080, but got 80
+`)
+}
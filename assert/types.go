@@ -0,0 +1,180 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// IsType checks whether the dynamic type of act is the same as that of exp, e.g.
+// IsType(t, "err", err, &os.PathError{}).
+func IsType(t testing.TB, name string, act, exp interface{}) bool {
+	t.Helper()
+	return New(t).IsType(name, act, exp)
+}
+
+// IsType is the same as the package-level IsType.
+func (a *Assertions) IsType(name string, act, exp interface{}) bool {
+	a.t.Helper()
+	actV, expV := reflect.ValueOf(act), reflect.ValueOf(exp)
+	if typeName(actV) == typeName(expV) && (!actV.IsValid() || actV.Type() == expV.Type()) {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is expected to be of type %s, but got %s: %s", name, typeName(expV), typeName(actV), indentValue(prettyValue(actV, false))))
+	return false
+}
+
+// Implements checks whether the dynamic type of act implements the interface iface points to, e.g.
+// Implements(t, "r", r, (*io.Reader)(nil)).
+func Implements(t testing.TB, name string, act, iface interface{}) bool {
+	t.Helper()
+	return New(t).Implements(name, act, iface)
+}
+
+// Implements is the same as the package-level Implements.
+func (a *Assertions) Implements(name string, act, iface interface{}) bool {
+	a.t.Helper()
+	ifaceT := reflect.TypeOf(iface)
+	if ifaceT == nil || ifaceT.Kind() != reflect.Ptr || ifaceT.Elem().Kind() != reflect.Interface {
+		a.fail(fmt.Sprintf("assert: %s: expecting a pointer to an interface, e.g. (*io.Reader)(nil), but got %v", name, ifaceT))
+		return false
+	}
+	ifaceT = ifaceT.Elem()
+	actT := reflect.TypeOf(act)
+	if actT == nil {
+		a.fail(fmt.Sprintf("%s is nil, which implements no interface, expecting %v", name, ifaceT))
+		return false
+	}
+	if actT.Implements(ifaceT) {
+		return true
+	}
+	var missing []string
+	for i := 0; i < ifaceT.NumMethod(); i++ {
+		if m := ifaceT.Method(i); !hasMethod(actT, m) {
+			missing = append(missing, m.Name+strings.TrimPrefix(m.Type.String(), "func"))
+		}
+	}
+	msg := fmt.Sprintf("%s of type %v does not implement %v, missing methods:\n  %s", name, actT, ifaceT, strings.Join(missing, "\n  "))
+	if actT.Kind() != reflect.Ptr && reflect.PtrTo(actT).Implements(ifaceT) {
+		msg += fmt.Sprintf("\nnote: %v implements it, some methods have pointer receivers", reflect.PtrTo(actT))
+	}
+	a.fail(msg)
+	return false
+}
+
+// hasMethod returns whether t has the method m of an interface with the same signature.
+func hasMethod(t reflect.Type, m reflect.Method) bool {
+	tm, ok := t.MethodByName(m.Name)
+	if !ok {
+		return false
+	}
+	if t.Kind() == reflect.Interface {
+		return tm.Type == m.Type
+	}
+	// The first input of a method of a concrete type is the receiver.
+	in := make([]reflect.Type, tm.Type.NumIn()-1)
+	for i := range in {
+		in[i] = tm.Type.In(i + 1)
+	}
+	out := make([]reflect.Type, tm.Type.NumOut())
+	for i := range out {
+		out[i] = tm.Type.Out(i)
+	}
+	return reflect.FuncOf(in, out, tm.Type.IsVariadic()) == m.Type
+}
+
+// isNil returns whether v is nil, or a nil pointer, map, slice, channel, func or interface.
+func isNil(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// Nil checks whether act is nil, including a typed nil, i.e. a nil pointer, map, slice, channel
+// or func in a non-nil interface{} value.
+func Nil(t testing.TB, name string, act interface{}) bool {
+	t.Helper()
+	return New(t).Nil(name, act)
+}
+
+// Nil is the same as the package-level Nil.
+func (a *Assertions) Nil(name string, act interface{}) bool {
+	a.t.Helper()
+	v := reflect.ValueOf(act)
+	if isNil(v) {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is expected to be nil, but got %s", name, typedValueMessage(act)))
+	return false
+}
+
+// NotNil checks whether act is not nil. A typed nil, e.g. a nil *T returned as an error, fails it
+// with a message explaining that it is not == nil as an interface value.
+func NotNil(t testing.TB, name string, act interface{}) bool {
+	t.Helper()
+	return New(t).NotNil(name, act)
+}
+
+// NotNil is the same as the package-level NotNil.
+func (a *Assertions) NotNil(name string, act interface{}) bool {
+	a.t.Helper()
+	v := reflect.ValueOf(act)
+	switch {
+	case !v.IsValid():
+		a.fail(fmt.Sprintf("%s is unexpectedly nil", name))
+	case isNil(v):
+		a.fail(fmt.Sprintf("%s is unexpectedly a typed nil %v: it is != nil as an interface value holding "+
+			"the type %v, but its value is nil", name, v.Type(), v.Type()))
+	default:
+		return true
+	}
+	return false
+}
+
+// Zero checks whether act is nil or the zero value of its type.
+func Zero(t testing.TB, name string, act interface{}) bool {
+	t.Helper()
+	return New(t).Zero(name, act)
+}
+
+// Zero is the same as the package-level Zero.
+func (a *Assertions) Zero(name string, act interface{}) bool {
+	a.t.Helper()
+	v := reflect.ValueOf(act)
+	if !v.IsValid() || v.IsZero() {
+		return true
+	}
+	a.fail(fmt.Sprintf("%s is expected to be the zero value of %v, but got %s", name, v.Type(), indentValue(prettyValue(v, false))))
+	return false
+}
+
+// NotZero checks whether act is neither nil nor the zero value of its type.
+func NotZero(t testing.TB, name string, act interface{}) bool {
+	t.Helper()
+	return New(t).NotZero(name, act)
+}
+
+// NotZero is the same as the package-level NotZero.
+func (a *Assertions) NotZero(name string, act interface{}) bool {
+	a.t.Helper()
+	v := reflect.ValueOf(act)
+	switch {
+	case !v.IsValid():
+		a.fail(fmt.Sprintf("%s is unexpectedly nil", name))
+	case v.IsZero():
+		a.fail(fmt.Sprintf("%s is unexpectedly the zero value %s", name, typedValueMessage(act)))
+	default:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+type ptrStringer struct{}
+
+func (*ptrStringer) String() string { return "" }
+
+func ExampleNotNil() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
+
+	var pe *os.PathError
+	var err error = pe
+	a.NotNil("err", err)
+
+	// OUTPUT:
+	// err is unexpectedly a typed nil *fs.PathError: it is != nil as an interface value holding the type *fs.PathError, but its value is nil
+}
+
+func TestIsType(t *testing.T) {
+	True(t, "IsType", IsType(t, "v", &os.PathError{}, (*os.PathError)(nil)))
+	True(t, "IsType", IsType(t, "v", nil, nil))
+	True(t, "Implements", Implements(t, "v", &bytesp.Slice{}, (*io.Writer)(nil)))
+	True(t, "Implements", Implements(t, "v", io.Reader(os.Stdin), (*io.Closer)(nil)))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "IsType", a.IsType("v", errors.New("a"), &os.PathError{}))
+	False(t, "IsType", a.IsType("v", nil, 1))
+	False(t, "Implements", a.Implements("v", ptrStringer{}, (*fmt.Stringer)(nil)))
+	False(t, "Implements", a.Implements("v", 1, (*io.ReadWriter)(nil)))
+	False(t, "Implements", a.Implements("v", nil, (*io.Reader)(nil)))
+	False(t, "Implements", a.Implements("v", 1, io.Reader(nil)))
+
+	StringEqual(t, "log", "\n"+string(b), `
+v is expected to be of type *fs.PathError, but got *errors.errorString: &errors.errorString{s: "a"}
+v is expected to be of type int, but got nil: nil
+v of type assert.ptrStringer does not implement fmt.Stringer, missing methods:
+  String() string
+note: *assert.ptrStringer implements it, some methods have pointer receivers
+v of type int does not implement io.ReadWriter, missing methods:
+  Read([]uint8) (int, error)
+  Write([]uint8) (int, error)
+v is nil, which implements no interface, expecting io.Reader
+assert: v: expecting a pointer to an interface, e.g. (*io.Reader)(nil), but got <nil>
+`)
+}
+
+func TestNil(t *testing.T) {
+	var pe *os.PathError
+	var err error = pe
+	True(t, "Nil", Nil(t, "v", nil))
+	True(t, "Nil", Nil(t, "v", err))
+	True(t, "Nil", Nil(t, "v", map[int]int(nil)))
+	True(t, "NotNil", NotNil(t, "v", 0))
+	True(t, "NotNil", NotNil(t, "v", []int{}))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "Nil", a.Nil("v", 0))
+	False(t, "Nil", a.Nil("v", []int{}))
+	False(t, "NotNil", a.NotNil("v", nil))
+	False(t, "NotNil", a.NotNil("v", []int(nil)))
+
+	StringEqual(t, "log", "\n"+string(b), `
+v is expected to be nil, but got 0(type int)
+v is expected to be nil, but got []int{}
+v is unexpectedly nil
+v is unexpectedly a typed nil []int: it is != nil as an interface value holding the type []int, but its value is nil
+`)
+}
+
+func TestZero(t *testing.T) {
+	True(t, "Zero", Zero(t, "v", nil))
+	True(t, "Zero", Zero(t, "v", ""))
+	True(t, "Zero", Zero(t, "v", struct{ A []int }{}))
+	True(t, "NotZero", NotZero(t, "v", []int{}))
+	True(t, "NotZero", NotZero(t, "v", 1))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "Zero", a.Zero("v", []int{}))
+	False(t, "Zero", a.Zero("v", struct{ A int }{A: 1}))
+	False(t, "NotZero", a.NotZero("v", nil))
+	False(t, "NotZero", a.NotZero("v", int64(0)))
+
+	StringEqual(t, "log", "\n"+string(b), `
+v is expected to be the zero value of []int, but got []int{}
+v is expected to be the zero value of struct { A int }, but got struct { A int }{A: 1}
+v is unexpectedly nil
+v is unexpectedly the zero value 0(type int64)
+`)
+}
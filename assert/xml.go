@@ -0,0 +1,330 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// MarkupOption customizes the comparison in XMLEqual and HTMLEqual.
+type MarkupOption func(*markupOptions)
+
+type markupOptions struct {
+	keepWhitespace bool
+	attrOrder      bool
+}
+
+// KeepWhitespace makes whitespaces in texts significant. By default, leading and trailing
+// whitespaces of texts are trimmed, other runs of whitespaces are treated as a single space and
+// whitespace-only texts are ignored.
+func KeepWhitespace() MarkupOption {
+	return func(o *markupOptions) {
+		o.keepWhitespace = true
+	}
+}
+
+// AttributeOrder makes the order of attributes of an element significant.
+func AttributeOrder() MarkupOption {
+	return func(o *markupOptions) {
+		o.attrOrder = true
+	}
+}
+
+// markupNode is an element, or a text node if name is empty. The root of a parsed document is
+// a node holding the top-level nodes as children.
+type markupNode struct {
+	name     string
+	attrs    []xml.Attr
+	text     string
+	children []*markupNode
+}
+
+func markupName(n xml.Name, html bool) string {
+	name := n.Local
+	if n.Space != "" {
+		name = n.Space + ":" + name
+	}
+	if html {
+		name = strings.ToLower(name)
+	}
+	return name
+}
+
+func parseMarkup(data []byte, html bool, o *markupOptions) (*markupNode, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	if html {
+		dec.Strict = false
+		dec.AutoClose = xml.HTMLAutoClose
+		dec.Entity = xml.HTMLEntity
+	}
+	root := &markupNode{}
+	stack := []*markupNode{root}
+	// Whether whitespaces were trimmed after the last text, which are kept as a space if the
+	// text is merged with a following one.
+	space := false
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		top := stack[len(stack)-1]
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &markupNode{name: markupName(tok.Name, html)}
+			for _, a := range tok.Attr {
+				a.Name.Local = markupName(a.Name, html)
+				a.Name.Space = ""
+				n.attrs = append(n.attrs, a)
+			}
+			top.children = append(top.children, n)
+			stack = append(stack, n)
+			space = false
+		case xml.EndElement:
+			space = false
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			text := string(tok)
+			l := len(top.children)
+			merge := l > 0 && top.children[l-1].name == ""
+			if !o.keepWhitespace {
+				trimmed := strings.Join(strings.Fields(text), " ")
+				if trimmed == "" {
+					space = space || text != ""
+					continue
+				}
+				if merge && (space || strings.TrimLeftFunc(text, unicode.IsSpace) != text) {
+					trimmed = " " + trimmed
+				}
+				space = strings.TrimRightFunc(text, unicode.IsSpace) != text
+				text = trimmed
+			}
+			if merge {
+				// Merge adjacent texts separated by comments, etc.
+				top.children[l-1].text += text
+				continue
+			}
+			top.children = append(top.children, &markupNode{text: text})
+		}
+	}
+	return root, nil
+}
+
+func (n *markupNode) writeTo(b *bytes.Buffer) {
+	if n.name == "" {
+		xml.EscapeText(b, []byte(n.text))
+		return
+	}
+	b.WriteString("<" + n.name)
+	for _, a := range n.attrs {
+		fmt.Fprintf(b, " %s=%q", a.Name.Local, a.Value)
+	}
+	b.WriteString(">")
+	for _, c := range n.children {
+		c.writeTo(b)
+	}
+	b.WriteString("</" + n.name + ">")
+}
+
+func (n *markupNode) String() string {
+	var b bytes.Buffer
+	n.writeTo(&b)
+	s := b.String()
+	if r := []rune(s); len(r) > 60 {
+		s = string(r[:57]) + "..."
+	}
+	return s
+}
+
+// childPaths returns XPath-like steps of children, e.g. "div[2]" or "text()". The index is
+// omitted if there is only one such child in both act and exp.
+func childPaths(act, exp []*markupNode) (actPaths, expPaths []string) {
+	count := func(l []*markupNode) map[string]int {
+		m := make(map[string]int)
+		for _, c := range l {
+			m[c.step()]++
+		}
+		return m
+	}
+	actCnt, expCnt := count(act), count(exp)
+	paths := func(l []*markupNode) []string {
+		idx := make(map[string]int)
+		res := make([]string, len(l))
+		for i, c := range l {
+			s := c.step()
+			idx[s]++
+			if actCnt[s] > 1 || expCnt[s] > 1 {
+				res[i] = fmt.Sprintf("%s[%d]", s, idx[s])
+			} else {
+				res[i] = s
+			}
+		}
+		return res
+	}
+	return paths(act), paths(exp)
+}
+
+func (n *markupNode) step() string {
+	if n.name == "" {
+		return "text()"
+	}
+	return n.name
+}
+
+func attrNames(attrs []xml.Attr) []string {
+	names := make([]string, len(attrs))
+	for i, a := range attrs {
+		names[i] = a.Name.Local
+	}
+	return names
+}
+
+func markupDiff(path string, act, exp *markupNode, o *markupOptions) []string {
+	if act.name != exp.name {
+		return []string{fmt.Sprintf("%s is expected to be %s, but got %s", path, exp, act)}
+	}
+	if exp.name == "" {
+		if act.text != exp.text {
+			return []string{fmt.Sprintf("%s is expected to be %q, but got %q", path, exp.text, act.text)}
+		}
+		return nil
+	}
+
+	var m []string
+	actAttrs, expAttrs := make(map[string]string), make(map[string]string)
+	for _, a := range act.attrs {
+		actAttrs[a.Name.Local] = a.Value
+	}
+	for _, a := range exp.attrs {
+		expAttrs[a.Name.Local] = a.Value
+	}
+	names := attrNames(act.attrs)
+	for _, a := range exp.attrs {
+		if _, ok := actAttrs[a.Name.Local]; !ok {
+			names = append(names, a.Name.Local)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := path + "/@" + name
+		actV, actOK := actAttrs[name]
+		expV, expOK := expAttrs[name]
+		switch {
+		case !expOK:
+			m = append(m, fmt.Sprintf("extra %s -> %q", p, actV))
+		case !actOK:
+			m = append(m, fmt.Sprintf("missing %s -> %q", p, expV))
+		case actV != expV:
+			m = append(m, fmt.Sprintf("%s is expected to be %q, but got %q", p, expV, actV))
+		}
+	}
+	if o.attrOrder && len(m) == 0 && !stringSliceEqual(attrNames(act.attrs), attrNames(exp.attrs)) {
+		m = append(m, fmt.Sprintf("attributes of %s are expected in order %v, but got %v", path,
+			attrNames(exp.attrs), attrNames(act.attrs)))
+	}
+	return append(m, childrenDiff(path, act.children, exp.children, o)...)
+}
+
+func childrenDiff(path string, act, exp []*markupNode, o *markupOptions) []string {
+	var m []string
+	actPaths, expPaths := childPaths(act, exp)
+	for i := 0; i < len(act) || i < len(exp); i++ {
+		switch {
+		case i >= len(exp):
+			m = append(m, fmt.Sprintf("extra %s/%s -> %s", path, actPaths[i], act[i]))
+		case i >= len(act):
+			m = append(m, fmt.Sprintf("missing %s/%s -> %s", path, expPaths[i], exp[i]))
+		default:
+			p := path + "/" + expPaths[i]
+			if actPaths[i] != expPaths[i] {
+				p = fmt.Sprintf("%s/node()[%d]", path, i+1)
+			}
+			m = append(m, markupDiff(p, act[i], exp[i], o)...)
+		}
+	}
+	return m
+}
+
+func parseMarkupValue(v interface{}, html bool, o *markupOptions) (*markupNode, error) {
+	var data []byte
+	switch v := v.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		if html {
+			return nil, fmt.Errorf("expecting a string or a []byte, but got %T", v)
+		}
+		var err error
+		if data, err = xml.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	return parseMarkup(data, html, o)
+}
+
+func (a *Assertions) markupEqual(lang, name string, act, exp interface{}, html bool, opts []MarkupOption) bool {
+	a.t.Helper()
+	o := &markupOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	actN, err := parseMarkupValue(act, html, o)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s is not valid %s: %v", name, lang, err))
+		return false
+	}
+	expN, err := parseMarkupValue(exp, html, o)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: expected %s is not valid %s: %v", name, lang, err))
+		return false
+	}
+	m, eq := joinDiffs(name, childrenDiff(name, actN.children, expN.children, o))
+	if !eq {
+		a.fail(m)
+	}
+	return eq
+}
+
+// XMLEqual compares two XML documents structurally, i.e. the order of attributes, comments and
+// insignificant whitespaces are ignored by default. act and exp could be a string, a []byte or any
+// value that is marshaled with encoding/xml first. Differences are reported with XPath-like
+// paths, e.g. "doc/root/item[2]/@id".
+func XMLEqual(t testing.TB, name string, act, exp interface{}, opts ...MarkupOption) bool {
+	t.Helper()
+	return New(t).XMLEqual(name, act, exp, opts...)
+}
+
+// XMLEqual is the same as the package-level XMLEqual.
+func (a *Assertions) XMLEqual(name string, act, exp interface{}, opts ...MarkupOption) bool {
+	a.t.Helper()
+	return a.markupEqual("XML", name, act, exp, false, opts)
+}
+
+// HTMLEqual is similar to XMLEqual but parses act and exp, a string or a []byte, as HTML: element
+// and attribute names are case-insensitive, void and unclosed elements and HTML entities are
+// allowed.
+func HTMLEqual(t testing.TB, name string, act, exp interface{}, opts ...MarkupOption) bool {
+	t.Helper()
+	return New(t).HTMLEqual(name, act, exp, opts...)
+}
+
+// HTMLEqual is the same as the package-level HTMLEqual.
+func (a *Assertions) HTMLEqual(name string, act, exp interface{}, opts ...MarkupOption) bool {
+	a.t.Helper()
+	return a.markupEqual("HTML", name, act, exp, true, opts)
+}
@@ -0,0 +1,70 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func ExampleHTMLEqual() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
+
+	a.HTMLEqual("page", `<UL class="list" id=items>
+  <li>one</li>
+  <li>two<br></li>
+</UL>`, `<ul id="items" class="menu"><li>one</li><li>two</li><li>three</li></ul>`)
+
+	// OUTPUT:
+	// page is unexpected:
+	//   page/ul/@class is expected to be "menu", but got "list"
+	//   extra page/ul/li[2]/br -> <br></br>
+	//   missing page/ul/li[3] -> <li>three</li>
+}
+
+func TestXMLEqual(t *testing.T) {
+	True(t, "return value", XMLEqual(t, "v", `<?xml version="1.0"?>
+<a x="1" y="2">
+  <!-- comment -->
+  <b>  text
+  here </b>
+</a>`, `<a y="2" x="1"><b>text here</b></a>`))
+	type A struct {
+		B string `xml:"b"`
+	}
+	True(t, "return value", XMLEqual(t, "v", A{B: "x"}, `<A><b>x</b></A>`))
+	True(t, "return value", XMLEqual(t, "v", `<a>x <!-- c --> y</a>`, `<a>x y</a>`))
+	True(t, "return value", XMLEqual(t, "v", `<a>x<!-- c --> <!-- d -->y</a>`, `<a>x y</a>`))
+	True(t, "return value", XMLEqual(t, "v", `<a>x<!-- c -->y</a>`, `<a>xy</a>`))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+
+	False(t, "return value", a.XMLEqual("v", `<a x="1" y="2"/>`, `<a y="2" x="1"/>`, AttributeOrder()))
+	False(t, "return value", a.XMLEqual("v", `<a> b </a>`, `<a>b</a>`, KeepWhitespace()))
+	False(t, "return value", a.XMLEqual("v", `<a><b/><c/></a>`, `<a><c/><c/></a>`))
+	False(t, "return value", a.XMLEqual("v", `<a>`, `<a/>`))
+	False(t, "return value", a.XMLEqual("v", `<r/>`, `<r><a>`+strings.Repeat("é", 60)+`</a></r>`))
+	False(t, "return value", a.HTMLEqual("v", 1, `<a/>`))
+	StringEqual(t, "output", "\n"+string(b), `
+v is unexpected:
+  attributes of v/a are expected in order [y x], but got [x y]
+v is unexpected:
+  v/a/text() is expected to be "b", but got " b "
+v is unexpected:
+  v/a/node()[1] is expected to be <c></c>, but got <b></b>
+assert: v is not valid XML: XML syntax error on line 1: unexpected EOF
+v is unexpected:
+  missing v/r/a -> <a>`+strings.Repeat("é", 54)+`...
+assert: v is not valid HTML: expecting a string or a []byte, but got int
+`)
+}
@@ -0,0 +1,162 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// normalizeDocument converts a value decoded by a YAML/TOML decoder into the form used by
+// documentDiff: maps with string keys by documentKey, []interface{} and json.Number for numbers.
+func normalizeDocument(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, string, bool, json.Number:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		return string(v)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return json.Number(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		return json.Number(strconv.FormatFloat(rv.Float(), 'g', -1, 64))
+	case reflect.Map:
+		m := make(map[string]interface{}, rv.Len())
+		for _, k := range rv.MapKeys() {
+			m[documentKey(k.Interface())] = normalizeDocument(rv.MapIndex(k).Interface())
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		l := make([]interface{}, rv.Len())
+		for i := range l {
+			l[i] = normalizeDocument(rv.Index(i).Interface())
+		}
+		return l
+	}
+	return fmt.Sprint(v)
+}
+
+// documentKey returns the string key of a mapping key. Keys of other types than string are tagged
+// with their YAML types, e.g. "!!int 1", so that they don't collide with string keys like "1". A
+// string key starting with "!!" is tagged with "!!str " for the same reason.
+func documentKey(k interface{}) string {
+	switch k := k.(type) {
+	case string:
+		if strings.HasPrefix(k, "!!") {
+			return "!!str " + k
+		}
+		return k
+	case nil:
+		return "!!null"
+	case bool:
+		return fmt.Sprintf("!!bool %v", k)
+	case time.Time:
+		return "!!timestamp " + k.Format(time.RFC3339Nano)
+	}
+	switch rv := reflect.ValueOf(k); rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("!!int %v", k)
+	case reflect.Float32, reflect.Float64:
+		return "!!float " + strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	}
+	return fmt.Sprintf("!!%T %v", k, k)
+}
+
+// documentsDiff compares two streams of documents. The i-th document is named as name#i in the
+// messages, unless both streams contain a single document.
+func documentsDiff(name string, act, exp []interface{}, o *compareOptions) (message string, equal bool) {
+	if len(act) == 1 && len(exp) == 1 {
+		return documentDiff(name, act[0], exp[0], o)
+	}
+	var m []string
+	for i := 0; i < len(act) || i < len(exp); i++ {
+		p := fmt.Sprintf("%s#%d", name, i)
+		switch {
+		case i >= len(exp):
+			m = append(m, fmt.Sprintf("extra document %s -> %s", p, documentValueMessage(act[i])))
+		case i >= len(act):
+			m = append(m, fmt.Sprintf("missing document %s -> %s", p, documentValueMessage(exp[i])))
+		default:
+			m = append(m, treeDiff(p, act[i], exp[i], o)...)
+		}
+	}
+	return joinDiffs(name, m)
+}
+
+func parseYAML(v interface{}) ([]interface{}, error) {
+	var data []byte
+	switch v := v.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		var err error
+		if data, err = yaml.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	var docs []interface{}
+	for {
+		var doc interface{}
+		if err := dec.Decode(&doc); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		docs = append(docs, normalizeDocument(doc))
+	}
+	if len(docs) == 0 {
+		// An empty stream is treated as a single null document.
+		docs = append(docs, nil)
+	}
+	return docs, nil
+}
+
+// YAMLEqual compares two YAML streams semantically, i.e. anchors and aliases are resolved and the
+// order of mapping keys and formatting are ignored. act and exp could be a string, a []byte or any
+// value that is marshaled with gopkg.in/yaml.v3 first. Multiple documents in a stream are
+// compared one by one. Differences are reported with paths like "config/servers/0/port".
+func YAMLEqual(t testing.TB, name string, act, exp interface{}, opts ...CompareOption) bool {
+	t.Helper()
+	return New(t).YAMLEqual(name, act, exp, opts...)
+}
+
+// YAMLEqual is the same as the package-level YAMLEqual.
+func (a *Assertions) YAMLEqual(name string, act, exp interface{}, opts ...CompareOption) bool {
+	a.t.Helper()
+	actDocs, err := parseYAML(act)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: %s is not valid YAML: %v", name, err))
+		return false
+	}
+	expDocs, err := parseYAML(exp)
+	if err != nil {
+		a.fail(fmt.Sprintf("assert: expected %s is not valid YAML: %v", name, err))
+		return false
+	}
+	if m, eq := documentsDiff(name, actDocs, expDocs, a.compareOptions(opts)); !eq {
+		a.fail(m)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assert
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golangplus/bytes"
+	"github.com/golangplus/testing"
+)
+
+func ExampleYAMLEqual() {
+	// The following line is for test/example of assert package itself. Use a *testing.T as t,
+	// i.e. New(t), in normal testing instead.
+	a := New(&testingp.WriterTB{Writer: os.Stdout}, FilePosition(false))
+
+	a.YAMLEqual("config", `
+defaults: &defaults
+  port: 80
+servers:
+- <<: *defaults
+  host: a
+- {host: b, port: 81}
+`, `
+servers:
+  - host: a
+    port: 8080
+  - host: b
+    port: 81
+defaults: {port: 80}
+`)
+
+	// OUTPUT:
+	// config is unexpected:
+	//   config/servers/0/port is expected to be 8080, but got 80
+}
+
+func TestYAMLEqual(t *testing.T) {
+	True(t, "return value", YAMLEqual(t, "v", "a: 1\nb: [x, y]\n---\nc: 2.50\n", "b:\n- x\n- y\na: 1.0\n---\n{c: 2.5}"))
+	True(t, "return value", YAMLEqual(t, "v", map[string]int{"a": 1}, `{"a": 1}`))
+	True(t, "return value", YAMLEqual(t, "v", "", "null"))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+
+	False(t, "return value", a.YAMLEqual("v", "a: 1\n---\nb: 2", "a: 1\n---\nb: 3\n---\nc: 4"))
+	False(t, "return value", a.YAMLEqual("v", "a: [", "a: 1"))
+	StringEqual(t, "output", "\n"+string(b), `
+v is unexpected:
+  v#1/b is expected to be 3, but got 2
+  missing document v#2 -> {"c":4}
+assert: v is not valid YAML: yaml: line 1: did not find expected node content
+`)
+}
+
+func TestYAMLEqual_KeyTypes(t *testing.T) {
+	True(t, "return value", YAMLEqual(t, "v", "1: a\n'2': b\n", "'2': b\n1: a\n"))
+	True(t, "return value", YAMLEqual(t, "v", "'!!int 1': a\n", "'!!int 1': a\n"))
+
+	var b bytesp.Slice
+	bt := &testingp.WriterTB{Writer: &b}
+	a := New(bt, FilePosition(false))
+	False(t, "return value", a.YAMLEqual("v", "1: a\n", "'1': a\n"))
+	False(t, "return value", a.YAMLEqual("v", "true: a\n2.5: b\n'!!int 1': c\n", "'true': a\n2.5: b\n1: c\n"))
+	StringEqual(t, "output", "\n"+string(b), `
+v is unexpected:
+  extra v/!!int 1 -> "a"
+  missing v/1 -> "a"
+v is unexpected:
+  extra v/!!bool true -> "a"
+  missing v/!!int 1 -> "c"
+  extra v/!!str !!int 1 -> "c"
+  missing v/true -> "a"
+`)
+}
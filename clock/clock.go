@@ -0,0 +1,94 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of time-dependent testing tools, e.g. polling assertions, so that
+// they can be tested deterministically with a Fake.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel receiving the current time after d.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// System is the Clock of the time package.
+var System Clock = systemClock{}
+
+// Fake is a Clock whose time only moves by Advance, or by After if AutoAdvance is set. It is
+// safe to use in multiple goroutines.
+type Fake struct {
+	// If true, After advances the time by d itself and returns a channel which is ready, so that
+	// code waiting in a loop runs without another goroutine calling Advance.
+	AutoAdvance bool
+
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	until time.Time
+	ch    chan time.Time
+}
+
+var _ Clock = (*Fake)(nil)
+
+// NewFake returns a Fake starting at now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (c *Fake) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Fake) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.mu.Lock()
+	c.waiters = append(c.waiters, fakeWaiter{until: c.now.Add(d), ch: ch})
+	c.mu.Unlock()
+	if c.AutoAdvance {
+		c.Advance(d)
+	} else if d <= 0 {
+		c.Advance(0)
+	}
+	return ch
+}
+
+// Advance moves the time forward by d and fires the channels returned by After whose time has
+// come.
+func (c *Fake) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.until.After(c.now) {
+			waiters = append(waiters, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = waiters
+}
+
+// Waiters returns the number of channels returned by After which have not fired yet. It can be
+// used to wait until the code under test is blocked on the clock before calling Advance.
+func (c *Fake) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
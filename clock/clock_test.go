@@ -0,0 +1,57 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := NewFake(start)
+
+	ch1, ch2 := c.After(time.Second), c.After(2*time.Second)
+	if n := c.Waiters(); n != 2 {
+		t.Errorf("Expected 2 waiters but got %d", n)
+	}
+	c.Advance(time.Second)
+	select {
+	case now := <-ch1:
+		if exp := start.Add(time.Second); !now.Equal(exp) {
+			t.Errorf("Expected %v but got %v", exp, now)
+		}
+	default:
+		t.Error("ch1 should be ready")
+	}
+	select {
+	case <-ch2:
+		t.Error("ch2 should not be ready")
+	default:
+	}
+	if n := c.Waiters(); n != 1 {
+		t.Errorf("Expected 1 waiter but got %d", n)
+	}
+
+	select {
+	case <-c.After(0):
+	default:
+		t.Error("After(0) should be ready")
+	}
+}
+
+func TestFake_AutoAdvance(t *testing.T) {
+	start := time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := NewFake(start)
+	c.AutoAdvance = true
+
+	<-c.After(time.Minute)
+	if now, exp := c.Now(), start.Add(time.Minute); !now.Equal(exp) {
+		t.Errorf("Expected %v but got %v", exp, now)
+	}
+	if n := c.Waiters(); n != 0 {
+		t.Errorf("Expected no waiters but got %d", n)
+	}
+}
@@ -0,0 +1,491 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testingp
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"unicode/utf8"
+)
+
+// Generator generates random values of a type for CheckProperty, and shrinks failing values.
+type Generator interface {
+	// Type returns the type of the values generated.
+	Type() reflect.Type
+	// Generate returns a random value whose complexity, e.g. the magnitude of a number or the length
+	// of a slice, is roughly bounded by size.
+	Generate(r *rand.Rand, size int) reflect.Value
+	// Shrink returns values simpler than v, the simplest first.
+	Shrink(v reflect.Value) []reflect.Value
+}
+
+// typeGenerator is the Generator derived from a type by reflection.
+type typeGenerator struct {
+	t reflect.Type
+}
+
+// GeneratorFor returns the Generator of values of t, derived by reflection. Supported are
+// booleans, numbers other than complex ones, strings, and arrays, slices, maps, pointers and
+// structs of supported types. Unexported fields of structs are left zero.
+func GeneratorFor(t reflect.Type) (Generator, error) {
+	if err := checkGeneratable(t, make(map[reflect.Type]bool)); err != nil {
+		return nil, err
+	}
+	return typeGenerator{t: t}, nil
+}
+
+func checkGeneratable(t reflect.Type, visited map[reflect.Type]bool) error {
+	if visited[t] {
+		return nil
+	}
+	visited[t] = true
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return nil
+	case reflect.Array, reflect.Slice, reflect.Ptr:
+		return checkGeneratable(t.Elem(), visited)
+	case reflect.Map:
+		if err := checkGeneratable(t.Key(), visited); err != nil {
+			return err
+		}
+		return checkGeneratable(t.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.PkgPath == "" {
+				if err := checkGeneratable(f.Type, visited); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("testingp: no generator for values of %v", t)
+}
+
+func (g typeGenerator) Type() reflect.Type { return g.t }
+
+func (g typeGenerator) Generate(r *rand.Rand, size int) reflect.Value {
+	v := reflect.New(g.t).Elem()
+	switch g.t.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if r.Intn(8) == 0 {
+			// Any value of the type, truncated by SetInt.
+			v.SetInt(int64(r.Uint64()))
+		} else {
+			v.SetInt(r.Int63n(2*int64(size)+1) - int64(size))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if r.Intn(8) == 0 {
+			v.SetUint(r.Uint64())
+		} else {
+			v.SetUint(uint64(r.Int63n(int64(size) + 1)))
+		}
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat((r.Float64()*2 - 1) * float64(size))
+	case reflect.String:
+		v.SetString(randomString(r, size))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			v.Index(i).Set(typeGenerator{g.t.Elem()}.Generate(r, size/2))
+		}
+	case reflect.Slice:
+		return generateSlice(r, size, typeGenerator{g.t.Elem()}, g.t)
+	case reflect.Map:
+		n := r.Intn(size + 1)
+		v.Set(reflect.MakeMapWithSize(g.t, n))
+		for i := 0; i < n; i++ {
+			v.SetMapIndex(typeGenerator{g.t.Key()}.Generate(r, size/2), typeGenerator{g.t.Elem()}.Generate(r, size/2))
+		}
+	case reflect.Ptr:
+		// Nil gets more likely as size decreases, so that recursive types are finite.
+		if size > 0 && r.Intn(4) > 0 {
+			p := reflect.New(g.t.Elem())
+			p.Elem().Set(typeGenerator{g.t.Elem()}.Generate(r, size/2))
+			v.Set(p)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				f.Set(typeGenerator{f.Type()}.Generate(r, size))
+			}
+		}
+	}
+	return v
+}
+
+// randomString returns a string of up to size runes, mostly printable ASCII ones.
+func randomString(r *rand.Rand, size int) string {
+	rs := make([]rune, r.Intn(size+1))
+	for i := range rs {
+		if r.Intn(4) > 0 {
+			rs[i] = rune(' ' + r.Intn('~'-' '+1))
+		} else if rs[i] = rune(0x80 + r.Int31n(utf8.MaxRune-0x80+1)); !utf8.ValidRune(rs[i]) {
+			rs[i] = utf8.RuneError
+		}
+	}
+	return string(rs)
+}
+
+func (g typeGenerator) Shrink(v reflect.Value) []reflect.Value {
+	var res []reflect.Value
+	add := func(set func(c reflect.Value)) {
+		c := reflect.New(g.t).Elem()
+		set(c)
+		res = append(res, c)
+	}
+	switch g.t.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			add(func(c reflect.Value) { c.SetBool(false) })
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		for _, x := range shrinkInt(v.Int()) {
+			if !v.OverflowInt(x) {
+				x := x
+				add(func(c reflect.Value) { c.SetInt(x) })
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		for _, x := range shrinkUint(v.Uint()) {
+			x := x
+			add(func(c reflect.Value) { c.SetUint(x) })
+		}
+	case reflect.Float32, reflect.Float64:
+		for _, x := range shrinkFloat(v.Float()) {
+			x := x
+			add(func(c reflect.Value) { c.SetFloat(x) })
+		}
+	case reflect.String:
+		rs := []rune(v.String())
+		for _, c := range shrinkSlice(reflect.ValueOf(rs), typeGenerator{reflect.TypeOf(rune(0))}) {
+			s := string(c.Interface().([]rune))
+			add(func(c reflect.Value) { c.SetString(s) })
+		}
+	case reflect.Array:
+		elem := typeGenerator{g.t.Elem()}
+		for i := 0; i < v.Len(); i++ {
+			for _, e := range elem.Shrink(v.Index(i)) {
+				i, e := i, e
+				add(func(c reflect.Value) {
+					c.Set(v)
+					c.Index(i).Set(e)
+				})
+			}
+		}
+	case reflect.Slice:
+		return shrinkSlice(v, typeGenerator{g.t.Elem()})
+	case reflect.Map:
+		return shrinkMap(v, typeGenerator{g.t.Elem()})
+	case reflect.Ptr:
+		if v.IsNil() {
+			break
+		}
+		res = append(res, reflect.Zero(g.t))
+		for _, e := range (typeGenerator{g.t.Elem()}).Shrink(v.Elem()) {
+			p := reflect.New(g.t.Elem())
+			p.Elem().Set(e)
+			res = append(res, p)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if g.t.Field(i).PkgPath != "" {
+				continue
+			}
+			for _, f := range (typeGenerator{g.t.Field(i).Type}).Shrink(v.Field(i)) {
+				i, f := i, f
+				add(func(c reflect.Value) {
+					c.Set(v)
+					c.Field(i).Set(f)
+				})
+			}
+		}
+	}
+	return res
+}
+
+// shrinkInt returns integers closer to 0 than x: 0, -x if x is negative, and then x - x/2,
+// x - x/4, ..., x - 1 (or x + 1 for negative x).
+func shrinkInt(x int64) []int64 {
+	if x == 0 {
+		return nil
+	}
+	res := []int64{0}
+	if x < 0 && x != math.MinInt64 {
+		res = append(res, -x)
+	}
+	for d := x / 2; d != 0; d /= 2 {
+		res = append(res, x-d)
+	}
+	return res
+}
+
+func shrinkUint(x uint64) []uint64 {
+	if x == 0 {
+		return nil
+	}
+	res := []uint64{0}
+	for d := x / 2; d != 0; d /= 2 {
+		res = append(res, x-d)
+	}
+	return res
+}
+
+// shrinkFloat returns numbers simpler than x: 0, -x if x is negative, and its integer part, or
+// those of shrinkInt if it is an integer.
+func shrinkFloat(x float64) []float64 {
+	if x == 0 {
+		return nil
+	}
+	if math.Trunc(x) == x && math.Abs(x) < 1<<53 {
+		var res []float64
+		for _, i := range shrinkInt(int64(x)) {
+			res = append(res, float64(i))
+		}
+		return res
+	}
+	res := []float64{0}
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return res
+	}
+	if x < 0 {
+		res = append(res, -x)
+	}
+	if t := math.Trunc(x); t != x {
+		res = append(res, t)
+	}
+	return res
+}
+
+// generateSlice returns a slice of type t with up to size elements generated by elem.
+func generateSlice(r *rand.Rand, size int, elem Generator, t reflect.Type) reflect.Value {
+	n := r.Intn(size + 1)
+	s := reflect.MakeSlice(t, n, n)
+	for i := 0; i < n; i++ {
+		s.Index(i).Set(elem.Generate(r, size/2))
+	}
+	return s
+}
+
+// shrinkSlice returns slices simpler than s: the empty slice, those with chunks of n/2, n/4, ...,
+// 1 elements removed, and those with an element shrunk by elem.
+func shrinkSlice(s reflect.Value, elem Generator) []reflect.Value {
+	n := s.Len()
+	if n == 0 {
+		return nil
+	}
+	res := []reflect.Value{reflect.MakeSlice(s.Type(), 0, 0)}
+	for k := n / 2; k > 0; k /= 2 {
+		for i := 0; i+k <= n; i += k {
+			c := reflect.MakeSlice(s.Type(), 0, n-k)
+			c = reflect.AppendSlice(c, s.Slice(0, i))
+			res = append(res, reflect.AppendSlice(c, s.Slice(i+k, n)))
+		}
+	}
+	for i := 0; i < n; i++ {
+		for _, e := range elem.Shrink(s.Index(i)) {
+			c := reflect.MakeSlice(s.Type(), n, n)
+			reflect.Copy(c, s)
+			c.Index(i).Set(e)
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// shrinkMap returns maps simpler than m: the empty map, those with a key removed, and those with a
+// value shrunk by elem.
+func shrinkMap(m reflect.Value, elem Generator) []reflect.Value {
+	if m.Len() == 0 {
+		return nil
+	}
+	clone := func(skip reflect.Value) reflect.Value {
+		c := reflect.MakeMapWithSize(m.Type(), m.Len())
+		for _, k := range m.MapKeys() {
+			if !skip.IsValid() || k.Interface() != skip.Interface() {
+				c.SetMapIndex(k, m.MapIndex(k))
+			}
+		}
+		return c
+	}
+	res := []reflect.Value{reflect.MakeMap(m.Type())}
+	// Sorted so that shrinking is reproducible.
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return formatValue(keys[i]) < formatValue(keys[j]) })
+	for _, k := range keys {
+		res = append(res, clone(k))
+	}
+	for _, k := range keys {
+		for _, e := range elem.Shrink(m.MapIndex(k)) {
+			c := clone(reflect.Value{})
+			c.SetMapIndex(k, e)
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+type intRange struct {
+	lo, hi int
+}
+
+// IntRange returns a Generator of ints in [lo, hi], shrinking toward the one closest to 0.
+func IntRange(lo, hi int) Generator {
+	if lo > hi {
+		panic(fmt.Sprintf("testingp: IntRange: lo %d is greater than hi %d", lo, hi))
+	}
+	return intRange{lo: lo, hi: hi}
+}
+
+func (g intRange) Type() reflect.Type { return reflect.TypeOf(0) }
+
+func (g intRange) Generate(r *rand.Rand, size int) reflect.Value {
+	// Computed in uint64, where hi - lo doesn't overflow.
+	span := uint64(int64(g.hi)) - uint64(int64(g.lo))
+	var x uint64
+	switch {
+	case span < math.MaxInt64:
+		x = uint64(r.Int63n(int64(span) + 1))
+	case span == math.MaxUint64:
+		x = r.Uint64()
+	default:
+		// Rejected with a probability less than 1/2.
+		for x = r.Uint64(); x > span; x = r.Uint64() {
+		}
+	}
+	return reflect.ValueOf(int(int64(uint64(int64(g.lo)) + x)))
+}
+
+func (g intRange) Shrink(v reflect.Value) []reflect.Value {
+	target := 0
+	switch {
+	case g.lo > 0:
+		target = g.lo
+	case g.hi < 0:
+		target = g.hi
+	}
+	// The distance from target to x, computed in uint64 to avoid overflows.
+	x, t := uint64(v.Int()), uint64(int64(target))
+	up := v.Int() >= int64(target)
+	dist := t - x
+	if up {
+		dist = x - t
+	}
+	var res []reflect.Value
+	for _, d := range shrinkUint(dist) {
+		c := t - d
+		if up {
+			c = t + d
+		}
+		res = append(res, reflect.ValueOf(int(int64(c))))
+	}
+	return res
+}
+
+type oneOf struct {
+	values []reflect.Value
+}
+
+// OneOf returns a Generator choosing one of values, which must be of the same type, shrinking
+// toward the first ones.
+func OneOf(values ...interface{}) Generator {
+	if len(values) == 0 {
+		panic("testingp: OneOf: no values")
+	}
+	g := oneOf{}
+	for _, v := range values {
+		rv := reflect.ValueOf(v)
+		if len(g.values) > 0 && rv.Type() != g.values[0].Type() {
+			panic(fmt.Sprintf("testingp: OneOf: values of different types %v and %v", g.values[0].Type(), rv.Type()))
+		}
+		g.values = append(g.values, rv)
+	}
+	return g
+}
+
+func (g oneOf) Type() reflect.Type { return g.values[0].Type() }
+
+func (g oneOf) Generate(r *rand.Rand, size int) reflect.Value {
+	return g.values[r.Intn(len(g.values))]
+}
+
+func (g oneOf) Shrink(v reflect.Value) []reflect.Value {
+	for i, c := range g.values {
+		if reflect.DeepEqual(c.Interface(), v.Interface()) {
+			return g.values[:i]
+		}
+	}
+	return nil
+}
+
+type sliceOf struct {
+	elem Generator
+}
+
+// SliceOf returns a Generator of slices whose elements are generated by elem.
+func SliceOf(elem Generator) Generator {
+	return sliceOf{elem: elem}
+}
+
+func (g sliceOf) Type() reflect.Type { return reflect.SliceOf(g.elem.Type()) }
+
+func (g sliceOf) Generate(r *rand.Rand, size int) reflect.Value {
+	return generateSlice(r, size, g.elem, g.Type())
+}
+
+func (g sliceOf) Shrink(v reflect.Value) []reflect.Value {
+	return shrinkSlice(v, g.elem)
+}
+
+// The maximum number of values generated by a Filter Generator for one satisfying its predicate.
+const maxFilterTries = 100
+
+type filter struct {
+	g    Generator
+	pred reflect.Value
+}
+
+// Filter returns a Generator of the values of g satisfying pred, a func(T) bool, where T is the
+// type of g. Generating panics if no values satisfy pred in 100 tries.
+func Filter(g Generator, pred interface{}) Generator {
+	p := reflect.ValueOf(pred)
+	if t := p.Type(); t.Kind() != reflect.Func || t.NumIn() != 1 || t.NumOut() != 1 ||
+		!g.Type().AssignableTo(t.In(0)) || t.Out(0).Kind() != reflect.Bool {
+		panic(fmt.Sprintf("testingp: Filter: expecting a func(%v) bool, but got %v", g.Type(), t))
+	}
+	return filter{g: g, pred: p}
+}
+
+func (g filter) Type() reflect.Type { return g.g.Type() }
+
+func (g filter) satisfies(v reflect.Value) bool {
+	return g.pred.Call([]reflect.Value{v})[0].Bool()
+}
+
+func (g filter) Generate(r *rand.Rand, size int) reflect.Value {
+	for i := 0; i < maxFilterTries; i++ {
+		if v := g.g.Generate(r, size); g.satisfies(v) {
+			return v
+		}
+	}
+	panic(fmt.Sprintf("testingp: Filter: no values of %v satisfy the predicate in %d tries", g.Type(), maxFilterTries))
+}
+
+func (g filter) Shrink(v reflect.Value) []reflect.Value {
+	var res []reflect.Value
+	for _, c := range g.g.Shrink(v) {
+		if g.satisfies(c) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
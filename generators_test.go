@@ -0,0 +1,181 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testingp
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func values(vs []reflect.Value) []interface{} {
+	res := make([]interface{}, len(vs))
+	for i, v := range vs {
+		res[i] = v.Interface()
+	}
+	return res
+}
+
+func TestShrinkNumbers(t *testing.T) {
+	for _, c := range []struct {
+		x   int64
+		exp []int64
+	}{
+		{0, nil},
+		{1, []int64{0}},
+		{10, []int64{0, 5, 8, 9}},
+		{-10, []int64{0, 10, -5, -8, -9}},
+	} {
+		if act := shrinkInt(c.x); !reflect.DeepEqual(act, c.exp) {
+			t.Errorf("shrinkInt(%d) is expected to be %v, but got %v", c.x, c.exp, act)
+		}
+	}
+	if act, exp := shrinkUint(10), []uint64{0, 5, 8, 9}; !reflect.DeepEqual(act, exp) {
+		t.Errorf("shrinkUint(10) is expected to be %v, but got %v", exp, act)
+	}
+	if act, exp := shrinkFloat(-2.5), []float64{0, 2.5, -2}; !reflect.DeepEqual(act, exp) {
+		t.Errorf("shrinkFloat(-2.5) is expected to be %v, but got %v", exp, act)
+	}
+	if act, exp := shrinkFloat(4), []float64{0, 2, 3}; !reflect.DeepEqual(act, exp) {
+		t.Errorf("shrinkFloat(4) is expected to be %v, but got %v", exp, act)
+	}
+
+	g, _ := GeneratorFor(reflect.TypeOf(int8(0)))
+	if act, exp := values(g.Shrink(reflect.ValueOf(int8(-128)))), []interface{}{int8(0), int8(-64), int8(-96),
+		int8(-112), int8(-120), int8(-124), int8(-126), int8(-127)}; !reflect.DeepEqual(act, exp) {
+		t.Errorf("Shrink(int8(-128)) is expected to be %v, but got %v", exp, act)
+	}
+}
+
+func TestShrinkComposites(t *testing.T) {
+	type pair struct {
+		A int
+		b int
+	}
+	for _, c := range []struct {
+		v   interface{}
+		exp []interface{}
+	}{
+		{true, []interface{}{false}},
+		{"ab", []interface{}{"", "b", "a", "\x00b", "1b", "Ib", "Ub", "[b", "^b", "`b", "a\x00", "a1", "aJ", "aV", "a\\", "a_", "aa"}},
+		{[]int{2, 1}, []interface{}{[]int{}, []int{1}, []int{2}, []int{0, 1}, []int{1, 1}, []int{2, 0}}},
+		{[2]bool{true, false}, []interface{}{[2]bool{false, false}}},
+		{map[string]bool{"a": true}, []interface{}{map[string]bool{}, map[string]bool{}, map[string]bool{"a": false}}},
+		{&pair{A: 2, b: 5}, []interface{}{(*pair)(nil), &pair{A: 0, b: 5}, &pair{A: 1, b: 5}}},
+	} {
+		g, err := GeneratorFor(reflect.TypeOf(c.v))
+		if err != nil {
+			t.Fatalf("GeneratorFor(%T) failed: %v", c.v, err)
+		}
+		if act := values(g.Shrink(reflect.ValueOf(c.v))); !reflect.DeepEqual(act, c.exp) {
+			t.Errorf("Shrink(%#v) is expected to be %#v, but got %#v", c.v, c.exp, act)
+		}
+	}
+}
+
+func TestGeneratorFor(t *testing.T) {
+	type tree struct {
+		Left, Right *tree
+		Labels      map[string][]uint16
+		ID          [2]float32
+		hidden      int
+	}
+	g, err := GeneratorFor(reflect.TypeOf(tree{}))
+	if err != nil {
+		t.Fatalf("GeneratorFor failed: %v", err)
+	}
+	r := rand.New(rand.NewSource(1))
+	for size := 0; size <= 100; size++ {
+		v := g.Generate(r, size).Interface().(tree)
+		if v.hidden != 0 {
+			t.Errorf("Expected unexported fields to be zero, but got %d", v.hidden)
+		}
+	}
+
+	sg, _ := GeneratorFor(reflect.TypeOf(""))
+	for i := 0; i < 100; i++ {
+		s := sg.Generate(r, 10).String()
+		if n := utf8.RuneCountInString(s); n > 10 || !utf8.ValidString(s) {
+			t.Errorf("Expected a valid string of up to 10 runes, but got %q", s)
+		}
+	}
+
+	if _, err := GeneratorFor(reflect.TypeOf(struct{ F func() }{})); err == nil || err.Error() != "testingp: no generator for values of func()" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestIntRange(t *testing.T) {
+	g := IntRange(-3, 3)
+	r := rand.New(rand.NewSource(1))
+	seen := map[int]bool{}
+	for i := 0; i < 100; i++ {
+		x := int(g.Generate(r, 0).Int())
+		if x < -3 || x > 3 {
+			t.Errorf("Expected a value in [-3, 3], but got %d", x)
+		}
+		seen[x] = true
+	}
+	if len(seen) != 7 {
+		t.Errorf("Expected all 7 values to be generated, but got %v", seen)
+	}
+	if act, exp := values(IntRange(5, 20).Shrink(reflect.ValueOf(13))), []interface{}{5, 9, 11, 12}; !reflect.DeepEqual(act, exp) {
+		t.Errorf("Shrink(13) is expected to be %v, but got %v", exp, act)
+	}
+	if act, exp := values(IntRange(-20, -5).Shrink(reflect.ValueOf(-7))), []interface{}{-5, -6}; !reflect.DeepEqual(act, exp) {
+		t.Errorf("Shrink(-7) is expected to be %v, but got %v", exp, act)
+	}
+
+	const maxInt = int(^uint(0) >> 1)
+	const minInt = -maxInt - 1
+	for _, c := range []struct{ lo, hi int }{{0, maxInt}, {minInt, maxInt}, {minInt, 0}, {-1, maxInt}, {maxInt, maxInt}} {
+		g := IntRange(c.lo, c.hi)
+		for i := 0; i < 100; i++ {
+			if x := int(g.Generate(r, 0).Int()); x < c.lo || x > c.hi {
+				t.Errorf("Expected a value in [%d, %d], but got %d", c.lo, c.hi, x)
+			}
+		}
+	}
+	if act, exp := values(IntRange(minInt, maxInt).Shrink(reflect.ValueOf(minInt))), []interface{}{0, minInt / 2, minInt / 4 * 3}; !reflect.DeepEqual(act[:3], exp) {
+		t.Errorf("Shrink(minInt) is expected to start with %v, but got %v", exp, act)
+	}
+	if act, exp := values(IntRange(-1, maxInt).Shrink(reflect.ValueOf(maxInt))), []interface{}{0, maxInt - maxInt/2}; !reflect.DeepEqual(act[:2], exp) {
+		t.Errorf("Shrink(maxInt) is expected to start with %v, but got %v", exp, act)
+	}
+}
+
+func TestOneOfSliceOfFilter(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := OneOf("a", "b", "c")
+	if act, exp := values(g.Shrink(reflect.ValueOf("c"))), []interface{}{"a", "b"}; !reflect.DeepEqual(act, exp) {
+		t.Errorf("Shrink(c) is expected to be %v, but got %v", exp, act)
+	}
+
+	sg := SliceOf(g)
+	if sg.Type() != reflect.TypeOf([]string(nil)) {
+		t.Errorf("Unexpected type %v", sg.Type())
+	}
+	for i := 0; i < 10; i++ {
+		for _, s := range sg.Generate(r, 5).Interface().([]string) {
+			if s != "a" && s != "b" && s != "c" {
+				t.Errorf("Unexpected element %q", s)
+			}
+		}
+	}
+	if act, exp := values(sg.Shrink(reflect.ValueOf([]string{"b"}))), []interface{}{[]string{}, []string{"a"}}; !reflect.DeepEqual(act, exp) {
+		t.Errorf("Shrink([b]) is expected to be %v, but got %v", exp, act)
+	}
+
+	even := Filter(IntRange(0, 100), func(x int) bool { return x%2 == 0 })
+	for i := 0; i < 100; i++ {
+		if x := even.Generate(r, 0).Int(); x%2 != 0 {
+			t.Errorf("Expected an even number, but got %d", x)
+		}
+	}
+	if act, exp := values(even.Shrink(reflect.ValueOf(10))), []interface{}{0, 8}; !reflect.DeepEqual(act, exp) {
+		t.Errorf("Shrink(10) is expected to be %v, but got %v", exp, act)
+	}
+}
@@ -1,8 +1,10 @@
 module github.com/golangplus/testing
 
-go 1.14
+go 1.16
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/golangplus/bytes v1.0.0
 	github.com/golangplus/fmt v1.0.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,161 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testingp
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Goroutine is a goroutine in the dump of runtime.Stack.
+type Goroutine struct {
+	ID int
+	// The state of the goroutine, e.g. "running" or "chan receive".
+	State string
+	// The stack trace, including the "goroutine N [state]:" line.
+	Stack string
+}
+
+// TopFunction returns the full name of the function at the top of the stack, e.g.
+// "net/http.(*conn).serve".
+func (g Goroutine) TopFunction() string {
+	lines := strings.SplitN(g.Stack, "\n", 3)
+	if len(lines) < 2 {
+		return ""
+	}
+	if p := strings.LastIndex(lines[1], "("); p > 0 {
+		return lines[1][:p]
+	}
+	return lines[1]
+}
+
+// parseGoroutines parses the dump of all goroutines from runtime.Stack.
+func parseGoroutines(dump string) []Goroutine {
+	var gs []Goroutine
+	for _, stack := range strings.Split(strings.TrimSpace(dump), "\n\n") {
+		header := stack
+		if p := strings.IndexByte(stack, '\n'); p >= 0 {
+			header = stack[:p]
+		}
+		// e.g. "goroutine 12 [chan receive, 2 minutes]:"
+		var g Goroutine
+		fields := strings.SplitN(strings.TrimPrefix(header, "goroutine "), " ", 2)
+		id, err := strconv.Atoi(fields[0])
+		if err != nil || len(fields) < 2 {
+			continue
+		}
+		g.ID, g.Stack = id, stack
+		g.State = strings.TrimSuffix(strings.TrimPrefix(fields[1], "["), "]:")
+		if p := strings.IndexByte(g.State, ','); p >= 0 {
+			g.State = g.State[:p]
+		}
+		gs = append(gs, g)
+	}
+	return gs
+}
+
+// Goroutines returns all the goroutines, except the calling one.
+func Goroutines() []Goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	gs := parseGoroutines(string(buf))
+	if len(gs) > 0 {
+		// The first one is the calling goroutine.
+		gs = gs[1:]
+	}
+	return gs
+}
+
+// GoroutineFilter returns whether a goroutine is known to be benign and is not a leak.
+type GoroutineFilter func(g Goroutine) bool
+
+// IgnoreTopFunction returns a GoroutineFilter ignoring goroutines whose top function is funcName,
+// e.g. "internal/poll.runtime_pollWait".
+func IgnoreTopFunction(funcName string) GoroutineFilter {
+	return func(g Goroutine) bool {
+		return g.TopFunction() == funcName
+	}
+}
+
+// IgnoreStackContaining returns a GoroutineFilter ignoring goroutines whose stack contains s.
+func IgnoreStackContaining(s string) GoroutineFilter {
+	return func(g Goroutine) bool {
+		return strings.Contains(g.Stack, s)
+	}
+}
+
+// isTestRunner returns whether g runs a test, e.g. a parallel test started after the snapshot.
+func isTestRunner(g Goroutine) bool {
+	return strings.Contains(g.Stack, "\ntesting.tRunner(")
+}
+
+// The maximum time to wait for goroutines to exit before reporting them as leaked.
+var LeakTimeout = time.Second
+
+// leakedGoroutines returns the goroutines not in existing and not ignored by the filters.
+func leakedGoroutines(existing map[int]bool, filters []GoroutineFilter) []Goroutine {
+	var leaked []Goroutine
+	for _, g := range Goroutines() {
+		if existing[g.ID] || isTestRunner(g) {
+			continue
+		}
+		ignored := false
+		for _, f := range filters {
+			if ignored = f(g); ignored {
+				break
+			}
+		}
+		if !ignored {
+			leaked = append(leaked, g)
+		}
+	}
+	return leaked
+}
+
+// CheckGoroutineLeaks snapshots the current goroutines and registers a cleanup on t which reports
+// the goroutines started after it, not ignored by the filters, that are still running at the end
+// of the test. Since goroutines may take some time to exit, it retries for up to LeakTimeout.
+// Goroutines running tests are always ignored.
+//
+// It should be called at the beginning of a test. Not to be used with t.Parallel().
+func CheckGoroutineLeaks(t testing.TB, filters ...GoroutineFilter) {
+	t.Helper()
+	existing := make(map[int]bool)
+	for _, g := range Goroutines() {
+		existing[g.ID] = true
+	}
+	t.Cleanup(func() {
+		t.Helper()
+		deadline := time.Now().Add(LeakTimeout)
+		for wait := time.Millisecond; ; wait *= 2 {
+			leaked := leakedGoroutines(existing, filters)
+			if len(leaked) == 0 {
+				return
+			}
+			if time.Now().After(deadline) {
+				stacks := make([]string, len(leaked))
+				for i, g := range leaked {
+					stacks[i] = g.Stack
+				}
+				t.Errorf("found %d leaked goroutine(s):\n\n%s", len(leaked), strings.Join(stacks, "\n\n"))
+				return
+			}
+			if max := 100 * time.Millisecond; wait > max {
+				wait = max
+			}
+			time.Sleep(wait)
+		}
+	})
+}
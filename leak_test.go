@@ -0,0 +1,88 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testingp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseGoroutines(t *testing.T) {
+	gs := parseGoroutines(`goroutine 1 [running]:
+main.main()
+	/tmp/main.go:10 +0x1d
+
+goroutine 7 [chan receive, 2 minutes]:
+main.(*worker).run(0xc000010000)
+	/tmp/main.go:20 +0x2e
+created by main.main
+	/tmp/main.go:9 +0x3f
+`)
+	if len(gs) != 2 {
+		t.Fatalf("Expected 2 goroutines but got %d", len(gs))
+	}
+	if g := gs[1]; g.ID != 7 || g.State != "chan receive" || g.TopFunction() != "main.(*worker).run" {
+		t.Errorf("Unexpected goroutine: %+v, top function: %q", g, g.TopFunction())
+	}
+	if g := gs[0]; g.ID != 1 || g.State != "running" || g.TopFunction() != "main.main" {
+		t.Errorf("Unexpected goroutine: %+v, top function: %q", g, g.TopFunction())
+	}
+}
+
+func blockForever(ch chan struct{}) {
+	<-ch
+}
+
+func selectForever(ch chan struct{}) {
+	select {
+	case <-ch:
+	}
+}
+
+func TestCheckGoroutineLeaks(t *testing.T) {
+	defer func(timeout time.Duration) { LeakTimeout = timeout }(LeakTimeout)
+	LeakTimeout = 50 * time.Millisecond
+
+	var b bytes.Buffer
+	wtb := &WriterTB{Writer: &b}
+	CheckGoroutineLeaks(wtb)
+	ch := make(chan struct{})
+	defer close(ch)
+	go blockForever(ch)
+	exited := make(chan struct{})
+	go func() {
+		time.Sleep(time.Millisecond)
+		close(exited)
+	}()
+	wtb.RunCleanups()
+
+	if !wtb.Failed() {
+		t.Fatal("wtb.Failed() should be true")
+	}
+	log := b.String()
+	if !strings.HasPrefix(log, "found 1 leaked goroutine(s):") || !strings.Contains(log, "testing.blockForever(") {
+		t.Errorf("Unexpected log: %s", log)
+	}
+}
+
+func TestCheckGoroutineLeaks_Filters(t *testing.T) {
+	defer func(timeout time.Duration) { LeakTimeout = timeout }(LeakTimeout)
+	LeakTimeout = 50 * time.Millisecond
+
+	var b bytes.Buffer
+	wtb := &WriterTB{Writer: &b}
+	CheckGoroutineLeaks(wtb, IgnoreTopFunction("github.com/golangplus/testing.blockForever"), IgnoreStackContaining("testing.selectForever("))
+	ch := make(chan struct{})
+	defer close(ch)
+	go blockForever(ch)
+	go selectForever(ch)
+	wtb.RunCleanups()
+
+	if wtb.Failed() {
+		t.Errorf("wtb.Failed() should be false, log: %s", b.String())
+	}
+}
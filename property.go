@@ -0,0 +1,278 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testingp
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Default settings of CheckProperty.
+var (
+	DefaultPropertyRuns = 100
+	DefaultMaxSize      = 50
+	DefaultMaxShrinks   = 1000
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+type propertyConfig struct {
+	runs       int
+	maxSize    int
+	maxShrinks int
+	seed       int64
+	seeded     bool
+	generators []Generator
+}
+
+// PropertyOption customizes CheckProperty.
+type PropertyOption func(*propertyConfig)
+
+// Runs sets the number of random arguments a property is checked on.
+func Runs(n int) PropertyOption {
+	return func(c *propertyConfig) {
+		c.runs = n
+	}
+}
+
+// Seed sets the seed of the random arguments, e.g. to replay a failure reported with its seed. It
+// defaults to TestSeed of the test.
+func Seed(seed int64) PropertyOption {
+	return func(c *propertyConfig) {
+		c.seed, c.seeded = seed, true
+	}
+}
+
+// MaxSize sets the size of the arguments of the last run. The size grows from small to it over the
+// runs. See Generator.Generate.
+func MaxSize(n int) PropertyOption {
+	return func(c *propertyConfig) {
+		c.maxSize = n
+	}
+}
+
+// MaxShrinks sets the maximum number of calls of a property when shrinking a counterexample.
+func MaxShrinks(n int) PropertyOption {
+	return func(c *propertyConfig) {
+		c.maxShrinks = n
+	}
+}
+
+// WithGenerators sets the generators of the arguments of a property, by position. Arguments
+// without one, or whose generator is nil, are generated by GeneratorFor their types.
+func WithGenerators(gs ...Generator) PropertyOption {
+	return func(c *propertyConfig) {
+		c.generators = gs
+	}
+}
+
+func (c *propertyConfig) validate() error {
+	switch {
+	case c.runs <= 0:
+		return fmt.Errorf("testingp: the number of runs must be positive, but got %d", c.runs)
+	case c.maxSize <= 0:
+		return fmt.Errorf("testingp: the max size must be positive, but got %d", c.maxSize)
+	case c.maxShrinks < 0:
+		return fmt.Errorf("testingp: the max shrinks must not be negative, but got %d", c.maxShrinks)
+	}
+	return nil
+}
+
+// property is a function checked by CheckProperty.
+type property struct {
+	f reflect.Value
+}
+
+// newProperty returns the property of prop, and the generators of its arguments.
+func newProperty(prop interface{}, gs []Generator) (property, []Generator, error) {
+	f := reflect.ValueOf(prop)
+	if f.Kind() != reflect.Func || f.Type().NumOut() != 1 ||
+		f.Type().Out(0) != reflect.TypeOf(true) && f.Type().Out(0) != errorType {
+		return property{}, nil, fmt.Errorf("testingp: expecting a property func returning a bool or an error, but got %T", prop)
+	}
+	t := f.Type()
+	if len(gs) > t.NumIn() {
+		return property{}, nil, fmt.Errorf("testingp: %d generators for a property of %d arguments", len(gs), t.NumIn())
+	}
+	res := make([]Generator, t.NumIn())
+	copy(res, gs)
+	for i := range res {
+		if res[i] == nil {
+			g, err := GeneratorFor(t.In(i))
+			if err != nil {
+				return property{}, nil, fmt.Errorf("%v, argument %d of the property", err, i)
+			}
+			res[i] = g
+		} else if !res[i].Type().AssignableTo(t.In(i)) {
+			return property{}, nil, fmt.Errorf("testingp: generator of %v for argument %d of type %v", res[i].Type(), i, t.In(i))
+		}
+	}
+	return property{f: f}, res, nil
+}
+
+// check calls the property with args and returns the description of the failure, or "" if it holds.
+func (p property) check(args []reflect.Value) (failure string) {
+	defer func() {
+		if r := recover(); r != nil {
+			failure = fmt.Sprintf("panic: %v", r)
+		}
+	}()
+	var out reflect.Value
+	if p.f.Type().IsVariadic() {
+		out = p.f.CallSlice(args)[0]
+	} else {
+		out = p.f.Call(args)[0]
+	}
+	if out.Kind() == reflect.Bool {
+		if !out.Bool() {
+			return "returned false"
+		}
+		return ""
+	}
+	if err, _ := out.Interface().(error); err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	return ""
+}
+
+// shrink shrinks the failing args by gs greedily, with up to maxCalls calls of p. Returns the
+// simplest args found, their failure and the number of steps shrunk.
+func (p property) shrink(args []reflect.Value, failure string, gs []Generator, maxCalls int) ([]reflect.Value, string, int) {
+	steps, calls := 0, 0
+	for shrunk := true; shrunk; {
+		shrunk = false
+		for i := 0; i < len(args) && !shrunk; i++ {
+			for _, c := range gs[i].Shrink(args[i]) {
+				if calls >= maxCalls {
+					return args, failure, steps
+				}
+				calls++
+				cand := append([]reflect.Value(nil), args...)
+				cand[i] = c
+				if f := p.check(cand); f != "" {
+					args, failure, shrunk = cand, f, true
+					steps++
+					break
+				}
+			}
+		}
+	}
+	return args, failure, steps
+}
+
+// generateArgs generates the arguments of a run by gs, returning a panic of a generator, e.g. one
+// of Filter, as an error.
+func generateArgs(gs []Generator, r *rand.Rand, size int) (args []reflect.Value, err error) {
+	i := 0
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("generating argument %d panicked: %v", i, p)
+		}
+	}()
+	args = make([]reflect.Value, len(gs))
+	for ; i < len(gs); i++ {
+		args[i] = gs[i].Generate(r, size)
+	}
+	return args, nil
+}
+
+// formatValue formats v in Go syntax as %#v does, but shows what pointers point to instead of
+// their addresses.
+func formatValue(v reflect.Value) string {
+	var elems []string
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return fmt.Sprintf("(%v)(nil)", v.Type())
+		}
+		return "&" + formatValue(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return fmt.Sprintf("%v(nil)", v.Type())
+		}
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			elems = append(elems, formatValue(v.Index(i)))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return fmt.Sprintf("%v(nil)", v.Type())
+		}
+		for _, k := range v.MapKeys() {
+			elems = append(elems, formatValue(k)+": "+formatValue(v.MapIndex(k)))
+		}
+		sort.Strings(elems)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			elems = append(elems, v.Type().Field(i).Name+": "+formatValue(v.Field(i)))
+		}
+	default:
+		// fmt formats the value held by a reflect.Value, even of an unexported field.
+		return fmt.Sprintf("%#v", v)
+	}
+	return fmt.Sprintf("%v{%s}", v.Type(), strings.Join(elems, ", "))
+}
+
+func argsString(args []reflect.Value) string {
+	s := make([]string, len(args))
+	for i, a := range args {
+		s[i] = formatValue(a)
+	}
+	return strings.Join(s, ", ")
+}
+
+// CheckProperty checks prop, a function returning a bool or an error, on random arguments, and
+// reports the failure through t if it returns false or a non-nil error, or panics. A failing
+// counterexample is shrunk to a simpler one before being reported, together with the seed to
+// replay it by Seed, or the -testingp.seed flag if the seed is the default TestSeed(t).
+//
+// Arguments are generated by GeneratorFor their types by default. See WithGenerators.
+func CheckProperty(t testing.TB, prop interface{}, opts ...PropertyOption) bool {
+	t.Helper()
+	cfg := propertyConfig{
+		runs:       DefaultPropertyRuns,
+		maxSize:    DefaultMaxSize,
+		maxShrinks: DefaultMaxShrinks,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if err := cfg.validate(); err != nil {
+		t.Error(err)
+		return false
+	}
+	p, gs, err := newProperty(prop, cfg.generators)
+	if err != nil {
+		t.Error(err)
+		return false
+	}
+	if !cfg.seeded {
+		cfg.seed = TestSeed(t)
+	}
+
+	r := rand.New(rand.NewSource(cfg.seed))
+	for run := 0; run < cfg.runs; run++ {
+		size := (run + 1) * cfg.maxSize / cfg.runs
+		args, err := generateArgs(gs, r, size)
+		if err != nil {
+			t.Errorf("property failed on run %d of %d, seed %d:\n  %v", run+1, cfg.runs, cfg.seed, err)
+			return false
+		}
+		failure := p.check(args)
+		if failure == "" {
+			continue
+		}
+		shrunk, failure, steps := p.shrink(args, failure, gs, cfg.maxShrinks)
+		t.Errorf("property failed on run %d of %d, seed %d:\n  args (shrunk in %d steps): %s\n  %s\n  original args: %s",
+			run+1, cfg.runs, cfg.seed, steps, argsString(shrunk), failure, argsString(args))
+		return false
+	}
+	return true
+}
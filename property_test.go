@@ -0,0 +1,167 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testingp
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheckProperty(t *testing.T) {
+	reversed := func(s []int) []int {
+		r := make([]int, len(s))
+		for i, v := range s {
+			r[len(s)-1-i] = v
+		}
+		return r
+	}
+	if !CheckProperty(t, func(s []int) bool {
+		return reflect.DeepEqual(reversed(reversed(s)), s)
+	}) {
+		t.Error("Expected the property to hold")
+	}
+
+	calls := 0
+	CheckProperty(t, func(int) bool {
+		calls++
+		return true
+	}, Runs(7))
+	if calls != 7 {
+		t.Errorf("Expected 7 runs, but got %d", calls)
+	}
+}
+
+func checkFailure(t *testing.T, prop interface{}, exp string, opts ...PropertyOption) {
+	t.Helper()
+	var b bytes.Buffer
+	wtb := &WriterTB{Writer: &b}
+	if CheckProperty(wtb, prop, append([]PropertyOption{Seed(1)}, opts...)...) {
+		t.Errorf("Expected the property to fail")
+	}
+	if !wtb.Failed() {
+		t.Errorf("Expected the TB to fail")
+	}
+	if !strings.Contains(b.String(), exp) {
+		t.Errorf("Expected the log to contain\n%s\nbut got\n%s", exp, b.String())
+	}
+}
+
+type node struct {
+	V    int
+	Next *node
+}
+
+func TestCheckProperty_Failures(t *testing.T) {
+	checkFailure(t, func(x int) bool { return x < 10 }, `property failed on run 8 of 100, seed 1:
+  args (shrunk in 61 steps): 10
+  returned false
+  original args: 4324745483838182873
+`)
+	checkFailure(t, func(s []int) bool {
+		sum := 0
+		for _, v := range s {
+			sum += v
+		}
+		return sum < 100
+	}, `
+  args (shrunk in 57 steps): []int{100}
+`)
+	checkFailure(t, func(s string) error {
+		if len(s) > 1 {
+			return errors.New("too long")
+		}
+		return nil
+	}, `
+  error: too long
+`)
+	checkFailure(t, func(n *node) bool { return n == nil || n.Next == nil }, `
+  args (shrunk in 1 steps): &testingp.node{V: 0, Next: &testingp.node{V: 0, Next: (*testingp.node)(nil)}}
+`)
+	checkFailure(t, func(x int) bool {
+		var s []int
+		return s[x] == 0
+	}, `
+  args (shrunk in 0 steps): 0
+  panic: runtime error: index out of range [0] with length 0
+`)
+	checkFailure(t, func(x int) bool { return x != 3 }, `
+  args (shrunk in 0 steps): 3
+`, WithGenerators(IntRange(3, 5)))
+}
+
+func TestCheckProperty_Replay(t *testing.T) {
+	var b1, b2 bytes.Buffer
+	prop := func(s []string, m map[int8]bool) bool { return len(s) < 5 || len(m) < 5 }
+	CheckProperty(&WriterTB{Writer: &b1}, prop, Seed(42))
+	CheckProperty(&WriterTB{Writer: &b2}, prop, Seed(42))
+	if b1.Len() == 0 || b1.String() != b2.String() {
+		t.Errorf("Expected the same failure with the same seed, but got\n%s\nand\n%s", b1.String(), b2.String())
+	}
+}
+
+func TestCheckProperty_Invalid(t *testing.T) {
+	for _, c := range []struct {
+		prop interface{}
+		opts []PropertyOption
+		exp  string
+	}{
+		{func(int) {}, nil, "testingp: expecting a property func returning a bool or an error, but got func(int)\n"},
+		{func(chan int) bool { return true }, nil, "testingp: no generator for values of chan int, argument 0 of the property\n"},
+		{func(string) bool { return true }, []PropertyOption{WithGenerators(IntRange(0, 1))},
+			"testingp: generator of int for argument 0 of type string\n"},
+		{func() bool { return true }, []PropertyOption{WithGenerators(IntRange(0, 1))},
+			"testingp: 1 generators for a property of 0 arguments\n"},
+		{func() bool { return true }, []PropertyOption{Runs(0)}, "testingp: the number of runs must be positive, but got 0\n"},
+		{func() bool { return true }, []PropertyOption{Runs(-1)}, "testingp: the number of runs must be positive, but got -1\n"},
+		{func() bool { return true }, []PropertyOption{MaxSize(0)}, "testingp: the max size must be positive, but got 0\n"},
+		{func() bool { return true }, []PropertyOption{MaxShrinks(-1)}, "testingp: the max shrinks must not be negative, but got -1\n"},
+	} {
+		var b bytes.Buffer
+		if CheckProperty(&WriterTB{Writer: &b}, c.prop, c.opts...) {
+			t.Errorf("Expected %T to be invalid", c.prop)
+		}
+		if b.String() != c.exp {
+			t.Errorf("Expected %q, but got %q", c.exp, b.String())
+		}
+	}
+}
+
+func TestCheckProperty_GeneratorPanic(t *testing.T) {
+	var b bytes.Buffer
+	wtb := &WriterTB{Writer: &b}
+	never := Filter(IntRange(0, 10), func(int) bool { return false })
+	if CheckProperty(wtb, func(x, y int) bool { return true }, WithGenerators(nil, never), Seed(5)) {
+		t.Error("Expected the property to fail")
+	}
+	if exp := "property failed on run 1 of 100, seed 5:\n  generating argument 1 panicked: testingp: Filter: no values of int satisfy the predicate in 100 tries\n"; b.String() != exp {
+		t.Errorf("Expected %q, but got %q", exp, b.String())
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	type pair struct {
+		a int
+		B []string
+	}
+	for _, c := range []struct {
+		v   interface{}
+		exp string
+	}{
+		{1, "1"},
+		{"a", `"a"`},
+		{[]int(nil), "[]int(nil)"},
+		{[2]bool{true}, "[2]bool{true, false}"},
+		{map[string]int{"b": 2, "a": 1}, `map[string]int{"a": 1, "b": 2}`},
+		{&pair{a: 1, B: []string{"x"}}, `&testingp.pair{a: 1, B: []string{"x"}}`},
+		{(*pair)(nil), "(*testingp.pair)(nil)"},
+	} {
+		if act := formatValue(reflect.ValueOf(c.v)); act != c.exp {
+			t.Errorf("formatValue(%#v) is expected to be %q, but got %q", c.v, c.exp, act)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testingp
+
+import (
+	"flag"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// The environment variable setting the seed of all tests, if the -testingp.seed flag is not set.
+const SeedEnv = "TESTINGP_SEED"
+
+var seedFlag = flag.String("testingp.seed", "", `the seed of random numbers of all tests, e.g. to replay a failure, or "random" for a new one for each test; derived from the test name if empty`)
+
+// Seeds of running tests, by their testing.TB.
+var testSeeds sync.Map
+
+// seedSetting returns the seed set by the -testingp.seed flag, or the environment variable
+// SeedEnv if the flag is not set.
+func seedSetting() string {
+	if *seedFlag != "" {
+		return *seedFlag
+	}
+	return os.Getenv(SeedEnv)
+}
+
+// nameSeed returns the seed derived from the name of a test.
+func nameSeed(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// runPattern returns the -run pattern matching exactly the test of name, quoted for shells. Each
+// element of a subtest name is matched separately, as -run splits patterns by "/".
+func runPattern(name string) string {
+	elems := strings.Split(name, "/")
+	for i, e := range elems {
+		elems[i] = "^" + regexp.QuoteMeta(e) + "$"
+	}
+	return "'" + strings.Replace(strings.Join(elems, "/"), "'", `'\''`, -1) + "'"
+}
+
+// TestSeed returns the seed of random numbers of the test t. It is the one set by the
+// -testingp.seed flag, or the environment variable TESTINGP_SEED if the flag is not set. The setting
+// "random" makes a new seed for each test. Without a setting, the seed is derived from t.Name(), so
+// that the test is reproducible.
+//
+// The same seed is returned for t until it finishes. If t fails, the seed and how to replay it are
+// logged by t when it finishes, i.e. in a function registered by t.Cleanup. The seed of t is kept
+// until then, so a testing.TB whose cleanups never run, e.g. a WriterTB without RunCleanups called,
+// keeps its seed in memory forever.
+func TestSeed(t testing.TB) int64 {
+	if seed, ok := testSeeds.Load(t); ok {
+		return seed.(int64)
+	}
+
+	var seed int64
+	switch s := seedSetting(); s {
+	case "":
+		seed = nameSeed(t.Name())
+	case "random":
+		seed = time.Now().UnixNano()
+	default:
+		var err error
+		if seed, err = strconv.ParseInt(s, 10, 64); err != nil {
+			t.Errorf("testingp: invalid seed %q, derived from the test name instead: %v", s, err)
+			seed = nameSeed(t.Name())
+		}
+	}
+	if actual, loaded := testSeeds.LoadOrStore(t, seed); loaded {
+		return actual.(int64)
+	}
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("testingp: random seed %d, replay with: go test -run %s -testingp.seed=%d", seed, runPattern(t.Name()), seed)
+		}
+		testSeeds.Delete(t)
+	})
+	return seed
+}
+
+// Rand returns a new *rand.Rand seeded by TestSeed(t). All of those returned for t generate the
+// same sequence.
+func Rand(t testing.TB) *rand.Rand {
+	return rand.New(rand.NewSource(TestSeed(t)))
+}
@@ -0,0 +1,111 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testingp
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func withSeedFlag(value string, f func()) {
+	defer func(old string) { *seedFlag = old }(*seedFlag)
+	*seedFlag = value
+	f()
+}
+
+func TestTestSeed(t *testing.T) {
+	withSeedFlag("", func() {
+		defer os.Unsetenv(SeedEnv)
+		os.Unsetenv(SeedEnv)
+
+		wtb := &WriterTB{TestName: "TestA"}
+		if seed := TestSeed(wtb); seed != nameSeed("TestA") {
+			t.Errorf("Expected the seed derived from the name, but got %d", seed)
+		}
+		if a, b := Rand(wtb).Int63(), Rand(wtb).Int63(); a != b {
+			t.Errorf("Expected the same sequences of a test, but got %d and %d", a, b)
+		}
+		if nameSeed("TestA") == nameSeed("TestB") {
+			t.Errorf("Expected different seeds of different names")
+		}
+
+		os.Setenv(SeedEnv, "7")
+		if seed := TestSeed(&WriterTB{}); seed != 7 {
+			t.Errorf("Expected the seed of the environment variable, but got %d", seed)
+		}
+		withSeedFlag("42", func() {
+			if seed := TestSeed(&WriterTB{}); seed != 42 {
+				t.Errorf("Expected the seed of the flag, but got %d", seed)
+			}
+		})
+		withSeedFlag("random", func() {
+			wtb := &WriterTB{}
+			if a, b := TestSeed(wtb), TestSeed(wtb); a != b {
+				t.Errorf("Expected the same random seed of a test, but got %d and %d", a, b)
+			}
+		})
+
+		var b bytes.Buffer
+		wtb = &WriterTB{Writer: &b, TestName: "TestA"}
+		withSeedFlag("x", func() {
+			if seed := TestSeed(wtb); seed != nameSeed("TestA") {
+				t.Errorf("Expected the seed derived from the name, but got %d", seed)
+			}
+		})
+		if exp := "testingp: invalid seed \"x\", derived from the test name instead: strconv.ParseInt: parsing \"x\": invalid syntax\n"; b.String() != exp {
+			t.Errorf("Expected %q, but got %q", exp, b.String())
+		}
+	})
+}
+
+func TestTestSeed_Log(t *testing.T) {
+	withSeedFlag("42", func() {
+		var b bytes.Buffer
+		wtb := &WriterTB{Writer: &b, TestName: "TestA/sub"}
+		TestSeed(wtb)
+		wtb.RunCleanups()
+		if b.Len() != 0 {
+			t.Errorf("Expected no logs of a passing test, but got %q", b.String())
+		}
+
+		TestSeed(wtb)
+		Rand(wtb)
+		wtb.Fail()
+		wtb.RunCleanups()
+		if exp := "testingp: random seed 42, replay with: go test -run '^TestA$/^sub$' -testingp.seed=42\n"; b.String() != exp {
+			t.Errorf("Expected %q, but got %q", exp, b.String())
+		}
+	})
+}
+
+func TestRunPattern(t *testing.T) {
+	for name, exp := range map[string]string{
+		"":                `'^$'`,
+		"TestA":           `'^TestA$'`,
+		"TestA/sub":       `'^TestA$/^sub$'`,
+		"TestA/case(1).x": `'^TestA$/^case\(1\)\.x$'`,
+		"TestA/it's":      `'^TestA$/^it'\''s$'`,
+	} {
+		if act := runPattern(name); act != exp {
+			t.Errorf("runPattern(%q) is expected to be %s, but got %s", name, exp, act)
+		}
+	}
+}
+
+func TestCheckProperty_TestSeed(t *testing.T) {
+	prop := func(x int) bool { return x < 10 }
+	var b1, b2 bytes.Buffer
+	CheckProperty(&WriterTB{Writer: &b1}, prop, Seed(3))
+	withSeedFlag("3", func() {
+		wtb := &WriterTB{Writer: &b2}
+		CheckProperty(wtb, prop)
+		wtb.RunCleanups()
+	})
+	exp := b1.String() + "testingp: random seed 3, replay with: go test -run '^$' -testingp.seed=3\n"
+	if b2.String() != exp {
+		t.Errorf("Expected\n%s\nbut got\n%s", exp, b2.String())
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testingp
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// RecordedRequest is a request sent through a RecordingTransport.
+type RecordedRequest struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
+
+	// The status code of the response, or 0 if the request failed.
+	StatusCode int
+	// The error of the request, if any.
+	Err error
+}
+
+// RecordingTransport is an http.RoundTripper recording the requests sent through it, for checking
+// them after a client under test talked to a local server, e.g. one of net/http/httptest.
+// Requests to hosts other than localhost and loopback addresses are refused without being sent.
+//
+// The zero value is ready to use, sending requests by http.DefaultTransport.
+type RecordingTransport struct {
+	// The RoundTripper sending the requests, e.g. httptest.Server.Client().Transport for TLS
+	// servers. http.DefaultTransport is used if nil.
+	Transport http.RoundTripper
+
+	mu       sync.Mutex
+	requests []RecordedRequest
+}
+
+var _ http.RoundTripper = (*RecordingTransport)(nil)
+
+// isLoopbackHost returns whether host, without the port, is localhost or a loopback address.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (rt *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL,
+		Header: req.Header.Clone(),
+	}
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		rec.Body = body
+		// A request must not be modified by a RoundTripper.
+		r := req.Clone(req.Context())
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		req = r
+	}
+
+	var resp *http.Response
+	if host := req.URL.Hostname(); !isLoopbackHost(host) {
+		rec.Err = fmt.Errorf("testingp: refusing to send a request to non-loopback host %q", host)
+	} else {
+		transport := rt.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		if resp, rec.Err = transport.RoundTrip(req); rec.Err == nil {
+			rec.StatusCode = resp.StatusCode
+		}
+	}
+
+	rt.mu.Lock()
+	rt.requests = append(rt.requests, rec)
+	rt.mu.Unlock()
+	return resp, rec.Err
+}
+
+// Requests returns the requests recorded so far, in the order of being sent.
+func (rt *RecordingTransport) Requests() []RecordedRequest {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	return append([]RecordedRequest(nil), rt.requests...)
+}
+
+// Reset removes the requests recorded.
+func (rt *RecordingTransport) Reset() {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.requests = nil
+}
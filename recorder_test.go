@@ -0,0 +1,71 @@
+// Copyright 2015 The Golang Plus Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testingp
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecordingTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(append([]byte("got "), body...))
+	}))
+	defer srv.Close()
+
+	rt := &RecordingTransport{}
+	client := &http.Client{Transport: rt}
+	resp, err := client.Post(srv.URL+"/items?x=1", "text/plain", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "got hello" {
+		t.Errorf("Expected the server to receive the body, but got %q", body)
+	}
+
+	if _, err := client.Get("http://example.com/"); err == nil || !strings.Contains(err.Error(), `refusing to send a request to non-loopback host "example.com"`) {
+		t.Errorf("Expected the request to a remote host to be refused, but got %v", err)
+	}
+
+	reqs := rt.Requests()
+	if len(reqs) != 2 {
+		t.Fatalf("Expected 2 requests, but got %d", len(reqs))
+	}
+	if r := reqs[0]; r.Method != "POST" || r.URL.Path != "/items" || r.URL.Query().Get("x") != "1" ||
+		r.Header.Get("Content-Type") != "text/plain" || string(r.Body) != "hello" || r.StatusCode != http.StatusCreated || r.Err != nil {
+		t.Errorf("Unexpected request: %+v", r)
+	}
+	if r := reqs[1]; r.Method != "GET" || r.URL.Host != "example.com" || r.StatusCode != 0 || r.Err == nil {
+		t.Errorf("Unexpected request: %+v", r)
+	}
+
+	rt.Reset()
+	if reqs := rt.Requests(); len(reqs) != 0 {
+		t.Errorf("Expected no requests after Reset, but got %d", len(reqs))
+	}
+}
+
+func TestIsLoopbackHost(t *testing.T) {
+	for host, exp := range map[string]bool{
+		"localhost":   true,
+		"127.0.0.1":   true,
+		"127.1.2.3":   true,
+		"::1":         true,
+		"example.com": false,
+		"10.0.0.1":    false,
+		"":            false,
+	} {
+		if act := isLoopbackHost(host); act != exp {
+			t.Errorf("isLoopbackHost(%q) is expected to be %v, but got %v", host, exp, act)
+		}
+	}
+}
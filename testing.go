@@ -11,6 +11,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"runtime"
+	"sync"
 	"testing"
 
 	"github.com/golangplus/fmt"
@@ -37,13 +39,47 @@ type WriterTB struct {
 	io.Writer
 	// The suffix for each log
 	Suffix string
+	// The name of the test returned by Name.
+	TestName string
 
 	failed  bool
 	skipped bool
+
+	mu sync.Mutex
+	// Names of functions marked by Helper.
+	helpers map[string]bool
+	// Functions registered by Cleanup.
+	cleanups []func()
 }
 
 var _ testing.TB = (*WriterTB)(nil)
 
+// Cleanup registers f to be called by RunCleanups. Unlike testing.T, they are not called
+// automatically.
+func (wtb *WriterTB) Cleanup(f func()) {
+	wtb.mu.Lock()
+	defer wtb.mu.Unlock()
+	wtb.cleanups = append(wtb.cleanups, f)
+}
+
+// RunCleanups calls the functions registered by Cleanup, in last added, first called order, and
+// removes them.
+func (wtb *WriterTB) RunCleanups() {
+	for {
+		wtb.mu.Lock()
+		n := len(wtb.cleanups)
+		if n == 0 {
+			wtb.mu.Unlock()
+			return
+		}
+		f := wtb.cleanups[n-1]
+		wtb.cleanups = wtb.cleanups[:n-1]
+		wtb.mu.Unlock()
+
+		f()
+	}
+}
+
 func (wtb *WriterTB) Error(args ...interface{}) {
 	wtb.Log(args...)
 	wtb.Fail()
@@ -93,6 +129,33 @@ func (wtb *WriterTB) Logf(format string, args ...interface{}) {
 	fmtp.Fprintfln(wtb.Writer, format, args...)
 }
 
+// Helper marks the calling function as a test helper function, see IsHelper.
+func (wtb *WriterTB) Helper() {
+	pc, _, _, ok := runtime.Caller(1)
+	if !ok {
+		return
+	}
+	wtb.mu.Lock()
+	defer wtb.mu.Unlock()
+	if wtb.helpers == nil {
+		wtb.helpers = make(map[string]bool)
+	}
+	wtb.helpers[runtime.FuncForPC(pc).Name()] = true
+}
+
+// IsHelper returns whether the function with the full name, e.g. "path/to/pkg.Func", has been
+// marked by Helper.
+func (wtb *WriterTB) IsHelper(funcName string) bool {
+	wtb.mu.Lock()
+	defer wtb.mu.Unlock()
+	return wtb.helpers[funcName]
+}
+
+// Name returns TestName as the name of the test.
+func (wtb *WriterTB) Name() string {
+	return wtb.TestName
+}
+
 func (wtb *WriterTB) Skip(args ...interface{}) {
 	wtb.Log(args...)
 	wtb.SkipNow()
@@ -6,6 +6,7 @@ package testingp
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 )
 
@@ -138,3 +139,40 @@ T: Skip: Now
 		t.Errorf("Expected logs:\n %v, but got: \n%v", expLogs, actLogs)
 	}
 }
+
+func TestWriterTB_Helper(t *testing.T) {
+	wtb := &WriterTB{}
+	func() {
+		wtb.Helper()
+	}()
+	if !wtb.IsHelper("github.com/golangplus/testing.TestWriterTB_Helper.func1") {
+		t.Error("the closure should be a helper")
+	}
+	if wtb.IsHelper("github.com/golangplus/testing.TestWriterTB_Helper") {
+		t.Error("TestWriterTB_Helper should not be a helper")
+	}
+}
+
+func TestWriterTB_Cleanup(t *testing.T) {
+	wtb := &WriterTB{}
+	var calls []int
+	wtb.Cleanup(func() { calls = append(calls, 1) })
+	wtb.Cleanup(func() {
+		calls = append(calls, 2)
+		wtb.Cleanup(func() { calls = append(calls, 3) })
+	})
+	wtb.RunCleanups()
+	wtb.RunCleanups()
+	if fmt.Sprint(calls) != "[2 3 1]" {
+		t.Errorf("Expected calls [2 3 1] but got %v", calls)
+	}
+}
+
+func TestWriterTB_Name(t *testing.T) {
+	if name := (&WriterTB{Suffix: "T"}).Name(); name != "" {
+		t.Errorf("Expected an empty name, but got %q", name)
+	}
+	if name := (&WriterTB{Suffix: "T", TestName: "TestA/sub"}).Name(); name != "TestA/sub" {
+		t.Errorf("Expected TestName as the name, but got %q", name)
+	}
+}